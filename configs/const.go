@@ -3,27 +3,74 @@ package configs
 import "time"
 
 const (
-	APIURL             = "/api/v1"
-	Port               = ":9000"
-	FileServerPort     = ":9001"
-	FileServerGRPCPort = ":9002"
-	SessionServicePort = ":9003"
-	Postgres           = "postgres"
-	ExpireTime         = time.Hour * 24
-	RedisAddr          = "localhost:6379"
-	ReqID              = "reqID"
-	UserID             = "userID"
-	SessionID          = "session_id"
-	CookieCSRF         = "X-CSRF-Cookie"
-	ImgMaxSize         = 1024 * 1024
-	AvatarFormFileKey  = "avatar"
-	ImgFormFileKey     = "image"
-	FileServerPath     = "./static"
-	ImageDir           = "./static/img/"
-	ProductID          = "product_id"
-	UserRole           = 1
-	AdminRole          = 2
-	TimeFormat         = "02.01.2006 15:04:05"
-	Longitude          = "longitude"
-	Latitude           = "latitude"
+	APIURL                = "/api/v1"
+	Port                  = ":9000"
+	FileServerPort        = ":9001"
+	FileServerGRPCPort    = ":9002"
+	SessionServicePort    = ":9003"
+	Postgres              = "postgres"
+	ExpireTime            = time.Hour * 24
+	RedisAddr             = "localhost:6379"
+	ReqID                 = "reqID"
+	UserID                = "userID"
+	SessionID             = "session_id"
+	CookieCSRF            = "X-CSRF-Cookie"
+	ImgMaxSize            = 1024 * 1024
+	AvatarFormFileKey     = "avatar"
+	ImgFormFileKey        = "image"
+	AttachmentFormFileKey = "attachment"
+	AttachmentTextKey     = "text"
+	FileServerPath        = "./static"
+	ImageDir              = "./static/img/"
+	ProductID             = "product_id"
+	Quantity              = "quantity"
+	UserRole              = 1
+	AdminRole             = 2
+	TimeFormat            = "02.01.2006 15:04:05"
+	Longitude             = "longitude"
+	Latitude              = "latitude"
+	Limit                 = "limit"
+	Offset                = "offset"
+	NearestLimit          = 20
+	After                 = "after"
+	Before                = "before"
+	ChatLimit             = 50
+	MaxChatLimit          = 100
+	EventsLimit           = 100
+	EventsTTL             = time.Hour * 24 * 7
+	MaxNearestLimit       = 100
+	OrderCancelWindow     = time.Minute * 5
+	PaymentFailedReason   = "payment failed"
+	OpeningTimeFormat     = "15:04"
+	ScheduleLeadTime      = time.Minute * 45
+	ScheduleHorizon       = time.Hour * 24 * 7
+	ReleaseLeadTime       = time.Hour
+	SchedulerInterval     = time.Minute
+	HolidayFormat         = "2006-01-02"
+
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	IdempotencyKeyTTL        = time.Hour * 24
+
+	HasMoreHeader              = "X-Has-More"
+	InterlocutorOnlineHeader   = "X-Interlocutor-Online"
+	InterlocutorLastSeenHeader = "X-Interlocutor-Last-Seen"
+
+	PaymentSignatureHeader = "Payment-Signature"
+
+	WebsocketSendQueue      = 256
+	WebsocketWriteWait      = time.Second * 10
+	WebsocketPongWait       = time.Minute
+	WebsocketPingPeriod     = WebsocketPongWait * 9 / 10
+	WebsocketMaxMessageSize = 1024 * 8
+	WebsocketChannelPrefix  = "ws:user:"
+	PresenceKeyPrefix       = "presence:user:"
+	LastSeenKeyPrefix       = "last_seen:user:"
+	StreamIDKeyPrefix       = "event_id:user:"
+	LastSeenTTL             = time.Hour * 24 * 30
+
+	LastEventIDHeader  = "Last-Event-ID"
+	EventRetention     = time.Minute * 2
+	EventRetentionSize = 100
+	EventsHeartbeat    = time.Second * 30
 )
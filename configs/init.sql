@@ -22,7 +22,37 @@ CREATE TABLE IF NOT EXISTS vendors (
     descript TEXT DEFAULT '' NOT NULL,
     picture TEXT DEFAULT '' NOT NULL,
     coordinates GEOGRAPHY NOT NULL,
-    service_radius INTEGER NOT NULL
+    service_radius INTEGER NOT NULL,
+    min_order_amount INTEGER DEFAULT 0 NOT NULL CHECK (min_order_amount >= 0),
+    delivery_zone GEOGRAPHY(MULTIPOLYGON, 4326),
+    time_zone TEXT DEFAULT 'Europe/Moscow' NOT NULL,
+    paused BOOLEAN DEFAULT false NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS opening_hours (
+    vendorID INTEGER NOT NULL,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+
+    FOREIGN KEY (vendorID) REFERENCES vendors (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS vendor_holidays (
+    vendorID INTEGER NOT NULL,
+    day DATE NOT NULL,
+
+    PRIMARY KEY (vendorID, day),
+    FOREIGN KEY (vendorID) REFERENCES vendors (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS delivery_fee_tiers (
+    vendorID INTEGER NOT NULL,
+    max_distance INTEGER NOT NULL CHECK (max_distance > 0),
+    fee INTEGER NOT NULL CHECK (fee >= 0),
+
+    PRIMARY KEY (vendorID, max_distance),
+    FOREIGN KEY (vendorID) REFERENCES vendors (id) ON DELETE CASCADE
 );
 
 CREATE TABLE IF NOT EXISTS products (
@@ -44,7 +74,8 @@ INSERT INTO categories (category) VALUES
     ('Завтраки'),
     ('Обеды'),
     ('Супы'),
-    ('Десерты');
+    ('Десерты')
+ON CONFLICT DO NOTHING;
 
 CREATE TABLE IF NOT EXISTS vendor_categories (
     vendorID INTEGER NOT NULL,
@@ -66,7 +97,9 @@ CREATE TABLE IF NOT EXISTS carts (
     userID INTEGER NOT NULL,
     productID INTEGER NOT NULL,
     vendorID INTEGER NOT NULL,
+    quantity INTEGER DEFAULT 1 NOT NULL CHECK (quantity > 0),
 
+    UNIQUE (userID, productID),
     FOREIGN KEY (userID) REFERENCES users (id) ON DELETE CASCADE,
     FOREIGN KEY (productID) REFERENCES products (id) ON DELETE CASCADE,
     FOREIGN KEY (vendorID) REFERENCES vendors (id) ON DELETE CASCADE
@@ -79,23 +112,59 @@ CREATE TABLE IF NOT EXISTS orders (
     vendorName TEXT NOT NULL,
     createdAt TIMESTAMPTZ NOT NULL,
     clientAddress TEXT NOT NULL,
-    orderStatus TEXT DEFAULT '' NOT NULL,
+    orderStatus TEXT DEFAULT 'created' NOT NULL,
     price INTEGER NOT NULL,
+    delivery_distance INTEGER DEFAULT 0 NOT NULL,
+    delivery_fee INTEGER DEFAULT 0 NOT NULL,
     reviewed BOOLEAN DEFAULT false NOT NULL,
+    cancel_reason TEXT DEFAULT '' NOT NULL,
+    payment_status TEXT DEFAULT 'pending' NOT NULL,
+    payment_intent TEXT DEFAULT '' NOT NULL,
+    deliver_at TIMESTAMPTZ,
+    released BOOLEAN DEFAULT true NOT NULL,
 
     FOREIGN KEY (userID) REFERENCES users (id),
     FOREIGN KEY (vendorID) REFERENCES vendors (id)
 );
 
 CREATE TABLE IF NOT EXISTS products_in_order (
+    id SERIAL NOT NULL PRIMARY KEY,
     orderID INTEGER NOT NULL,
     productName TEXT NOT NULL,
     price INTEGER NOT NULL,
     picture TEXT DEFAULT '' NOT NULL,
+    quantity INTEGER DEFAULT 1 NOT NULL CHECK (quantity > 0),
 
     FOREIGN KEY (orderID) REFERENCES orders (id)
 );
 
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id SERIAL NOT NULL PRIMARY KEY,
+    orderID INTEGER NOT NULL,
+    orderStatus TEXT NOT NULL,
+    userID INTEGER,
+    comment TEXT DEFAULT '' NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL,
+
+    FOREIGN KEY (orderID) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (userID) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id SERIAL NOT NULL PRIMARY KEY,
+    orderID INTEGER NOT NULL,
+    itemID INTEGER,
+    quantity INTEGER DEFAULT 0 NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason TEXT DEFAULT '' NOT NULL,
+    initiatorID INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    FOREIGN KEY (orderID) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (itemID) REFERENCES products_in_order (id) ON DELETE CASCADE,
+    FOREIGN KEY (initiatorID) REFERENCES users (id)
+);
+
 CREATE TABLE IF NOT EXISTS reviews (
     userID INTEGER NOT NULL,
     orderID INTEGER NOT NULL,
@@ -110,11 +179,103 @@ CREATE TABLE IF NOT EXISTS reviews (
 );
 
 CREATE TABLE IF NOT EXISTS messages (
+    id SERIAL NOT NULL PRIMARY KEY,
     orderID INTEGER NOT NULL,
     userID INTEGER NOT NULL,
     message_text TEXT NOT NULL,
+    attachment TEXT DEFAULT '' NOT NULL,
     sent_at TIMESTAMPTZ NOT NULL,
 
     FOREIGN KEY (orderID) REFERENCES orders (id),
     FOREIGN KEY (userID) REFERENCES users (id)
 );
+
+CREATE TABLE IF NOT EXISTS chat_reads (
+    orderID INTEGER NOT NULL,
+    userID INTEGER NOT NULL,
+    read_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (orderID, userID),
+    FOREIGN KEY (orderID) REFERENCES orders (id),
+    FOREIGN KEY (userID) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id SERIAL NOT NULL PRIMARY KEY,
+    userID INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    FOREIGN KEY (userID) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    userID INTEGER NOT NULL,
+    idem_key TEXT NOT NULL,
+    path TEXT NOT NULL,
+    request_hash TEXT DEFAULT '' NOT NULL,
+    status_code INTEGER DEFAULT 0 NOT NULL,
+    body BYTEA DEFAULT '' NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (userID, idem_key),
+    FOREIGN KEY (userID) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- The statements below bring a database created by an older version of this
+-- file up to date. They change nothing on a fresh database.
+
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS min_order_amount INTEGER DEFAULT 0 NOT NULL CHECK (min_order_amount >= 0);
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS delivery_zone GEOGRAPHY(MULTIPOLYGON, 4326);
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS time_zone TEXT DEFAULT 'Europe/Moscow' NOT NULL;
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS paused BOOLEAN DEFAULT false NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns WHERE table_name = 'carts' AND column_name = 'quantity'
+    ) THEN
+        -- a cart used to hold a row per added item, fold them into quantities
+        CREATE TEMPORARY TABLE cart_quantities AS
+            SELECT userID, productID, vendorID, COUNT(*) AS quantity FROM carts GROUP BY userID, productID, vendorID;
+        DELETE FROM carts;
+        ALTER TABLE carts ADD COLUMN quantity INTEGER DEFAULT 1 NOT NULL CHECK (quantity > 0);
+        INSERT INTO carts (userID, productID, vendorID, quantity)
+            SELECT userID, productID, vendorID, quantity FROM cart_quantities;
+        DROP TABLE cart_quantities;
+        ALTER TABLE carts ADD UNIQUE (userID, productID);
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns WHERE table_name = 'orders' AND column_name = 'payment_status'
+    ) THEN
+        -- orders placed before payments were taken count as paid, cancelled
+        -- ones have nothing to refund
+        ALTER TABLE orders ADD COLUMN payment_status TEXT DEFAULT 'paid' NOT NULL;
+        ALTER TABLE orders ALTER COLUMN payment_status SET DEFAULT 'pending';
+        UPDATE orders SET payment_status = 'pending' WHERE orderStatus IN ('cancelled', 'rejected');
+    END IF;
+END $$;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_distance INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancel_reason TEXT DEFAULT '' NOT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_intent TEXT DEFAULT '' NOT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMPTZ;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS released BOOLEAN DEFAULT true NOT NULL;
+ALTER TABLE orders ALTER COLUMN orderStatus SET DEFAULT 'created';
+
+-- statuses used to be free text set by partners: an order without one is new,
+-- any other unknown status means the partner has already taken it
+UPDATE orders SET orderStatus = 'created' WHERE orderStatus = '';
+UPDATE orders SET orderStatus = 'accepted'
+WHERE orderStatus NOT IN ('created', 'accepted', 'cooking', 'delivering', 'delivered', 'cancelled', 'rejected');
+
+ALTER TABLE products_in_order ADD COLUMN IF NOT EXISTS id SERIAL NOT NULL PRIMARY KEY;
+ALTER TABLE products_in_order ADD COLUMN IF NOT EXISTS quantity INTEGER DEFAULT 1 NOT NULL CHECK (quantity > 0);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS id SERIAL NOT NULL PRIMARY KEY;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS attachment TEXT DEFAULT '' NOT NULL;
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS request_hash TEXT DEFAULT '' NOT NULL;
@@ -5,23 +5,38 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker"
+	memoryBroker "github.com/friends/internal/pkg/broker/memory"
+	redisBroker "github.com/friends/internal/pkg/broker/redis"
 	cartDelivery "github.com/friends/internal/pkg/cart/delivery"
 	cartRepo "github.com/friends/internal/pkg/cart/repository"
 	cartUsecase "github.com/friends/internal/pkg/cart/usecase"
 	chatDelivery "github.com/friends/internal/pkg/chat/delivery"
 	chatRepository "github.com/friends/internal/pkg/chat/repository"
 	chatUsecase "github.com/friends/internal/pkg/chat/usecase"
+	eventsDelivery "github.com/friends/internal/pkg/events/delivery"
+	eventsRepository "github.com/friends/internal/pkg/events/repository"
+	eventsUsecase "github.com/friends/internal/pkg/events/usecase"
 	"github.com/friends/internal/pkg/fileserver"
+	staticGeocoder "github.com/friends/internal/pkg/geocoder/static"
+	idempotencyRepo "github.com/friends/internal/pkg/idempotency/repository"
 	"github.com/friends/internal/pkg/middleware"
 	orderDelivery "github.com/friends/internal/pkg/order/delivery"
 	orderRepo "github.com/friends/internal/pkg/order/repository"
+	orderScheduler "github.com/friends/internal/pkg/order/scheduler"
 	orderUsecase "github.com/friends/internal/pkg/order/usecase"
 	partnerDelivery "github.com/friends/internal/pkg/partner/delivery"
+	fakePayment "github.com/friends/internal/pkg/payment/fake"
 	profileDelivery "github.com/friends/internal/pkg/profile/delivery"
 	profileRepo "github.com/friends/internal/pkg/profile/repository"
 	profileUsecase "github.com/friends/internal/pkg/profile/usecase"
+	refundDelivery "github.com/friends/internal/pkg/refund/delivery"
+	refundRepository "github.com/friends/internal/pkg/refund/repository"
+	refundUsecase "github.com/friends/internal/pkg/refund/usecase"
 	reviewDelivery "github.com/friends/internal/pkg/review/delivery"
 	reviewRepository "github.com/friends/internal/pkg/review/repository"
 	reviewUsecase "github.com/friends/internal/pkg/review/usecase"
@@ -33,6 +48,7 @@ import (
 	vendorRepo "github.com/friends/internal/pkg/vendors/repository"
 	vendorUsecase "github.com/friends/internal/pkg/vendors/usecase"
 	websocketpool "github.com/friends/internal/pkg/websocketPool"
+	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	logrus "github.com/sirupsen/logrus"
@@ -94,18 +110,73 @@ func StartAPIServer(dsn string) {
 
 	partnerDelivery := partnerDelivery.New(userUsecase, profUsecase, sessionClient, vendUsecase)
 
-	wsPool := websocketpool.NewWebsocketPool()
+	var wsBroker broker.Broker
+	if os.Getenv("websocket_broker") == "memory" {
+		wsBroker = memoryBroker.New()
+	} else {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     configs.RedisAddr,
+			Password: "",
+			DB:       0,
+		})
+		defer redisClient.Close()
+		wsBroker = redisBroker.New(redisClient)
+	}
+	defer wsBroker.Close()
+
+	wsPool := websocketpool.NewWebsocketPool(wsBroker)
+	err = wsPool.Listen()
+	if err != nil {
+		logrus.Error(fmt.Errorf("websocket broker not available: %w", err))
+		return
+	}
 
 	orderRepo := orderRepo.New(db)
-	orderUsecase := orderUsecase.New(orderRepo, vendRepo)
+	webhookSecret := os.Getenv("payment_webhook_secret")
+	if webhookSecret == "" {
+		logrus.Warn("payment webhook secret is not set, payment webhooks are disabled")
+	}
+	paymentProvider := fakePayment.New(webhookSecret)
+
+	refundRepository := refundRepository.New(db)
+	refundUsecase := refundUsecase.New(refundRepository, orderRepo, vendRepo, paymentProvider)
+	refundDelivery := refundDelivery.New(refundUsecase)
+
+	geocoder, err := staticGeocoder.Load(os.Getenv("geocoder_addresses"))
+	if err != nil {
+		logrus.Error(fmt.Errorf("geocoder not available: %w", err))
+		return
+	}
+
+	eventsRepo := eventsRepository.New(db)
+	eventsUsecase := eventsUsecase.New(eventsRepo, wsPool)
+	eventsDelivery := eventsDelivery.New(eventsUsecase, wsPool)
+
+	orderUsecase := orderUsecase.New(
+		orderRepo, vendRepo, cartRepo, paymentProvider, refundUsecase, geocoder, eventsUsecase,
+	)
 	orderDelivery := orderDelivery.New(orderUsecase, vendUsecase, wsPool)
 
+	releaseLead := configs.ReleaseLeadTime
+	if lead := os.Getenv("scheduled_order_release_lead"); lead != "" {
+		releaseLead, err = time.ParseDuration(lead)
+		if err != nil {
+			logrus.Error(fmt.Errorf("wrong scheduled order release lead: %w", err))
+			return
+		}
+	}
+
+	stopScheduler := make(chan struct{})
+	defer close(stopScheduler)
+	orderScheduler := orderScheduler.New(orderUsecase, configs.SchedulerInterval, releaseLead)
+	go orderScheduler.Run(stopScheduler)
+
 	reviewRepository := reviewRepository.New(db)
 	reviewUsecase := reviewUsecase.New(reviewRepository, orderRepo, profRepo, vendRepo)
 	reviewDelivery := reviewDelivery.New(reviewUsecase)
 
 	chatRepository := chatRepository.New(db)
-	chatUsecase := chatUsecase.New(chatRepository, profRepo, orderRepo)
+	chatUsecase := chatUsecase.New(chatRepository, fileserverClient)
 	chatDelivery := chatDelivery.New(chatUsecase, orderUsecase, vendUsecase, wsPool)
 
 	accessRighsChecker := middleware.NewAccessRightsChecker(userUsecase)
@@ -113,6 +184,9 @@ func StartAPIServer(dsn string) {
 	authChecker := middleware.NewAuthChecker(sessionClient)
 	csrfChecker := middleware.NewCSRFChecker(authChecker)
 
+	idempotencyRepo := idempotencyRepo.New(db)
+	idempotencyChecker := middleware.NewIdempotencyChecker(idempotencyRepo)
+
 	mux := mux.NewRouter().PathPrefix(configs.APIURL).Subrouter()
 
 	mux.HandleFunc("/users", userHandler.Create).Methods("POST")
@@ -138,13 +212,34 @@ func StartAPIServer(dsn string) {
 		"/vendors/{id}",
 		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.UpdateVendor, configs.AdminRole)),
 	).Methods("PUT")
+	mux.HandleFunc("/vendors/{id}/delivery", vendDelivery.GetDeliverySettings).Methods("GET")
+	mux.Handle(
+		"/vendors/{id}/delivery",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.UpdateDeliverySettings, configs.AdminRole)),
+	).Methods("PUT")
+	mux.HandleFunc("/vendors/{id}/zone", vendDelivery.GetDeliveryZone).Methods("GET")
+	mux.Handle(
+		"/vendors/{id}/zone",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.UpdateDeliveryZone, configs.AdminRole)),
+	).Methods("PUT")
+	mux.HandleFunc("/vendors/{id}/schedule", vendDelivery.GetSchedule).Methods("GET")
+	mux.Handle(
+		"/vendors/{id}/schedule",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.UpdateSchedule, configs.AdminRole)),
+	).Methods("PUT")
+	mux.Handle(
+		"/vendors/{id}/pause",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.SetPaused, configs.AdminRole)),
+	).Methods("PUT")
 	mux.Handle(
 		"/vendors/{id}/pictures",
 		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.UpdateVendorPicture, configs.AdminRole)),
 	).Methods("PUT")
 	mux.Handle(
 		"/vendors/{id}/products",
-		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(partnerDelivery.AddProductToVendor, configs.AdminRole)),
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(
+			idempotencyChecker.Check(partnerDelivery.AddProductToVendor), configs.AdminRole,
+		)),
 	).Methods("POST")
 	mux.Handle(
 		"/vendors/{vendorID}/products/{id}",
@@ -164,6 +259,12 @@ func StartAPIServer(dsn string) {
 	).Methods("GET")
 	mux.HandleFunc("/vendors/{id}/reviews", reviewDelivery.GetVendorReviews).Methods("GET")
 	mux.Handle("/vendors/{vendorID}/orders/{id}", csrfChecker.Check(orderDelivery.UpdateOrderStatus)).Methods("PUT")
+	mux.Handle(
+		"/vendors/{vendorID}/orders/{id}/refunds",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(
+			idempotencyChecker.Check(refundDelivery.RefundOrder), configs.AdminRole,
+		)),
+	).Methods("POST")
 	mux.Handle(
 		"/vendors/{id}/chats",
 		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(chatDelivery.GetVendorChats, configs.AdminRole)),
@@ -172,20 +273,34 @@ func StartAPIServer(dsn string) {
 
 	mux.HandleFunc("/partners", partnerDelivery.Create).Methods("POST")
 	mux.Handle("/partners/vendors", csrfChecker.Check(partnerDelivery.GetPartnerShops)).Methods("GET")
+	mux.Handle("/partners/events", csrfChecker.Check(eventsDelivery.GetEvents)).Methods("GET")
+	mux.Handle(
+		"/partners/chats/unread",
+		csrfChecker.Check(accessRighsChecker.AccessRightsCheck(chatDelivery.GetUnreadCount, configs.AdminRole)),
+	).Methods("GET")
 
 	mux.Handle("/carts", csrfChecker.Check(cartDelivery.AddToCart)).Methods("PUT")
 	mux.Handle("/carts", csrfChecker.Check(cartDelivery.RemoveFromCart)).Methods("DELETE")
 	mux.Handle("/carts", csrfChecker.Check(cartDelivery.GetCart)).Methods("GET")
 
-	mux.Handle("/orders", csrfChecker.Check(orderDelivery.AddOrder)).Methods("POST")
+	mux.Handle("/orders", csrfChecker.Check(idempotencyChecker.Check(orderDelivery.AddOrder))).Methods("POST")
 	mux.Handle("/orders", csrfChecker.Check(orderDelivery.GetUserOrders)).Methods("GET")
+	mux.Handle("/orders/checkout", csrfChecker.Check(idempotencyChecker.Check(orderDelivery.Checkout))).Methods("POST")
 	mux.Handle("/orders/{id}", csrfChecker.Check(orderDelivery.GetOrder)).Methods("GET")
+	mux.Handle("/orders/{id}/history", csrfChecker.Check(orderDelivery.GetOrderHistory)).Methods("GET")
+	mux.Handle("/orders/{id}/cancel", csrfChecker.Check(orderDelivery.CancelOrder)).Methods("POST")
+
+	if webhookSecret != "" {
+		mux.HandleFunc("/payments/webhook", orderDelivery.PaymentWebhook).Methods("POST")
+	}
 
-	mux.Handle("/reviews", csrfChecker.Check(reviewDelivery.AddReview)).Methods("POST")
+	mux.Handle("/reviews", csrfChecker.Check(idempotencyChecker.Check(reviewDelivery.AddReview))).Methods("POST")
 	mux.Handle("/reviews", csrfChecker.Check(reviewDelivery.GetUserReviews)).Methods("GET")
 
 	mux.Handle("/ws", authChecker.Check(chatDelivery.Upgrade)).Methods("GET")
+	mux.Handle("/events", authChecker.Check(eventsDelivery.Stream)).Methods("GET")
 	mux.Handle("/chats/{id}", csrfChecker.Check(chatDelivery.GetChat)).Methods("GET")
+	mux.Handle("/chats/{id}/attachments", csrfChecker.Check(chatDelivery.SendAttachment)).Methods("POST")
 
 	mux.HandleFunc("/categories", vendDelivery.GetAllCategories).Methods("GET")
 
@@ -0,0 +1,37 @@
+package broker
+
+import "time"
+
+// Handler delivers a message published for the user to the local
+// connections of the user.
+type Handler func(userID string, msg []byte) error
+
+// Broker passes websocket messages between API instances, so a message
+// reaches the user whichever instance holds the user's connections.
+// It also keeps the presence of the users, shared by all the instances.
+//
+//go:generate mockgen -destination=./broker_mock.go -package=broker -self_package=github.com/friends/internal/pkg/broker github.com/friends/internal/pkg/broker Broker
+type Broker interface {
+	Publish(userID string, msg []byte) error
+	// Subscribe sets the handler of the messages of the users this
+	// instance is subscribed to.
+	Subscribe(handler Handler) error
+	// SubscribeUser starts passing the messages of the user to the handler.
+	// It's called on the first local connection of the user.
+	SubscribeUser(userID string) error
+	// UnsubscribeUser stops it once the last local connection is closed.
+	UnsubscribeUser(userID string) error
+	// SetOnline marks the connection of the user alive until ttl passes.
+	// It's called again while the connection is alive.
+	SetOnline(userID string, connID string, ttl time.Duration) error
+	// SetOffline removes the connection and remembers when the user was seen.
+	SetOffline(userID string, connID string) error
+	// Presence tells whether the user has an alive connection to any instance
+	// and, if not, when the last one was closed. lastSeen is zero when unknown.
+	Presence(userID string) (online bool, lastSeen time.Time, err error)
+	// NextStreamID returns the event stream id of the next message of the
+	// user. The ids grow across all the instances. They are a cursor of the
+	// stream only and have nothing to do with the ids of the saved events.
+	NextStreamID(userID string) (int64, error)
+	Close() error
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/friends/internal/pkg/broker"
+)
+
+// Broker hands messages to the subscribers of the same process. It's meant
+// for a single instance setup and tests.
+type Broker struct {
+	mu       *sync.RWMutex
+	handlers *[]broker.Handler
+	online   map[string]map[string]time.Time
+	lastSeen map[string]time.Time
+	lastID   *int64
+}
+
+func New() broker.Broker {
+	var lastID int64
+	return Broker{
+		mu:       &sync.RWMutex{},
+		handlers: &[]broker.Handler{},
+		online:   make(map[string]map[string]time.Time),
+		lastSeen: make(map[string]time.Time),
+		lastID:   &lastID,
+	}
+}
+
+// Publish delivers the message synchronously, so the error of a handler is
+// returned to the publisher.
+func (b Broker) Publish(userID string, msg []byte) error {
+	b.mu.RLock()
+	handlers := *b.handlers
+	b.mu.RUnlock()
+
+	var lastErr error
+	for _, handler := range handlers {
+		err := handler(userID, msg)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (b Broker) Subscribe(handler broker.Handler) error {
+	b.mu.Lock()
+	*b.handlers = append(*b.handlers, handler)
+	b.mu.Unlock()
+
+	return nil
+}
+
+// SubscribeUser does nothing, there are no other instances to get the
+// messages from.
+func (b Broker) SubscribeUser(userID string) error {
+	return nil
+}
+
+func (b Broker) UnsubscribeUser(userID string) error {
+	return nil
+}
+
+func (b Broker) SetOnline(userID string, connID string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	conns, ok := b.online[userID]
+	if !ok {
+		conns = make(map[string]time.Time)
+		b.online[userID] = conns
+	}
+	conns[connID] = time.Now().Add(ttl)
+
+	return nil
+}
+
+func (b Broker) SetOffline(userID string, connID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.online[userID], connID)
+	if len(b.online[userID]) == 0 {
+		delete(b.online, userID)
+	}
+	b.lastSeen[userID] = time.Now()
+
+	return nil
+}
+
+func (b Broker) Presence(userID string) (bool, time.Time, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	for _, expiresAt := range b.online[userID] {
+		if expiresAt.After(now) {
+			return true, time.Time{}, nil
+		}
+	}
+
+	return false, b.lastSeen[userID], nil
+}
+
+// NextStreamID uses one counter for all the users, the ids of a user still grow.
+func (b Broker) NextStreamID(userID string) (int64, error) {
+	return atomic.AddInt64(b.lastID, 1), nil
+}
+
+func (b Broker) Close() error {
+	b.mu.Lock()
+	*b.handlers = nil
+	b.mu.Unlock()
+
+	return nil
+}
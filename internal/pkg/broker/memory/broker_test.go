@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	broker := New()
+
+	received := make([]string, 0)
+	_ = broker.Subscribe(func(userID string, msg []byte) error {
+		received = append(received, userID+":"+string(msg))
+		return nil
+	})
+	_ = broker.Subscribe(func(userID string, msg []byte) error {
+		return fmt.Errorf("slow client")
+	})
+
+	err := broker.Publish("1", []byte("hello"))
+
+	if err == nil {
+		t.Errorf("expected handler error. Got nil")
+	}
+
+	if len(received) != 1 || received[0] != "1:hello" {
+		t.Errorf("expected: [1:hello]\n got: %v", received)
+	}
+
+	_ = broker.Close()
+
+	err = broker.Publish("1", []byte("hello"))
+
+	if err != nil || len(received) != 1 {
+		t.Errorf("expected no delivery after close")
+	}
+}
@@ -0,0 +1,209 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker"
+	log "github.com/friends/pkg/logger"
+	"github.com/go-redis/redis/v8"
+)
+
+// Broker publishes messages to a per-user Redis channel. An instance
+// listens only to the channels of the users connected to it, so a message
+// reaches just the instances holding the user's connections.
+// The alive connections of a user are kept in a sorted set scored
+// by their expiration time, the last seen time in a separate key.
+// Message ids come from a per-user counter.
+type Broker struct {
+	client *redis.Client
+	mu     *sync.Mutex
+	pubsub **redis.PubSub
+}
+
+func New(client *redis.Client) broker.Broker {
+	var pubsub *redis.PubSub
+	return Broker{
+		client: client,
+		mu:     &sync.Mutex{},
+		pubsub: &pubsub,
+	}
+}
+
+func channel(userID string) string {
+	return configs.WebsocketChannelPrefix + userID
+}
+
+func (b Broker) Publish(userID string, msg []byte) error {
+	err := b.client.Publish(context.Background(), channel(userID), msg).Err()
+	if err != nil {
+		return fmt.Errorf("couldn't publish message for user %v: %w", userID, err)
+	}
+
+	return nil
+}
+
+// Subscribe sets up the subscription the user channels are added to. The
+// messages are handled in the background.
+func (b Broker) Subscribe(handler broker.Handler) error {
+	ctx := context.Background()
+	err := b.client.Ping(ctx).Err()
+	if err != nil {
+		return fmt.Errorf("couldn't subscribe to user channels: %w", err)
+	}
+
+	pubsub := b.client.Subscribe(ctx)
+
+	b.mu.Lock()
+	*b.pubsub = pubsub
+	b.mu.Unlock()
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			userID := strings.TrimPrefix(msg.Channel, configs.WebsocketChannelPrefix)
+
+			err := handler(userID, []byte(msg.Payload))
+			if err != nil {
+				log.ErrorMessage(err.Error())
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (b Broker) SubscribeUser(userID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if *b.pubsub == nil {
+		return fmt.Errorf("couldn't subscribe to user %v: broker isn't subscribed", userID)
+	}
+
+	err := (*b.pubsub).Subscribe(context.Background(), channel(userID))
+	if err != nil {
+		return fmt.Errorf("couldn't subscribe to user %v: %w", userID, err)
+	}
+
+	return nil
+}
+
+func (b Broker) UnsubscribeUser(userID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if *b.pubsub == nil {
+		return nil
+	}
+
+	err := (*b.pubsub).Unsubscribe(context.Background(), channel(userID))
+	if err != nil {
+		return fmt.Errorf("couldn't unsubscribe from user %v: %w", userID, err)
+	}
+
+	return nil
+}
+
+func presenceKey(userID string) string {
+	return configs.PresenceKeyPrefix + userID
+}
+
+func lastSeenKey(userID string) string {
+	return configs.LastSeenKeyPrefix + userID
+}
+
+func streamIDKey(userID string) string {
+	return configs.StreamIDKeyPrefix + userID
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func (b Broker) SetOnline(userID string, connID string, ttl time.Duration) error {
+	ctx := context.Background()
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, presenceKey(userID), "-inf", strconv.FormatInt(millis(now), 10))
+		pipe.ZAdd(ctx, presenceKey(userID), &redis.Z{
+			Score:  float64(millis(expiresAt)),
+			Member: connID,
+		})
+		pipe.Expire(ctx, presenceKey(userID), ttl)
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("couldn't set user %v online: %w", userID, err)
+	}
+
+	return nil
+}
+
+func (b Broker) SetOffline(userID string, connID string) error {
+	ctx := context.Background()
+
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, presenceKey(userID), connID)
+		pipe.Set(ctx, lastSeenKey(userID), strconv.FormatInt(time.Now().UnixNano(), 10), configs.LastSeenTTL)
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("couldn't set user %v offline: %w", userID, err)
+	}
+
+	return nil
+}
+
+func (b Broker) Presence(userID string) (bool, time.Time, error) {
+	ctx := context.Background()
+
+	now := "(" + strconv.FormatInt(millis(time.Now()), 10)
+	alive, err := b.client.ZCount(ctx, presenceKey(userID), now, "+inf").Result()
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("couldn't get presence of user %v: %w", userID, err)
+	}
+
+	if alive != 0 {
+		return true, time.Time{}, nil
+	}
+
+	lastSeen, err := b.client.Get(ctx, lastSeenKey(userID)).Int64()
+	if err == redis.Nil {
+		return false, time.Time{}, nil
+	}
+
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("couldn't get last seen time of user %v: %w", userID, err)
+	}
+
+	return false, time.Unix(0, lastSeen), nil
+}
+
+func (b Broker) NextStreamID(userID string) (int64, error) {
+	id, err := b.client.Incr(context.Background(), streamIDKey(userID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get next stream id of user %v: %w", userID, err)
+	}
+
+	return id, nil
+}
+
+func (b Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if *b.pubsub == nil {
+		return nil
+	}
+
+	return (*b.pubsub).Close()
+}
@@ -0,0 +1,249 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestPublish(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("couldn't start redis: %v", err)
+	}
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	broker := New(client)
+
+	err = broker.SubscribeUser("1")
+	if err == nil {
+		t.Errorf("expected error before subscribe. Got nil")
+	}
+
+	received := make(chan string, 2)
+	err = broker.Subscribe(func(userID string, msg []byte) error {
+		received <- userID + ":" + string(msg)
+		return fmt.Errorf("no connections")
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = broker.SubscribeUser("1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitSubscribers(t, client, "1", 1)
+
+	// user without local connections
+	err = broker.Publish("2", []byte("hello"))
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// handler errors don't stop the delivery
+	for i := 0; i < 2; i++ {
+		err = broker.Publish("1", []byte("hello"))
+
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		select {
+		case msg := <-received:
+			if msg != "1:hello" {
+				t.Errorf("expected: 1:hello\n got: %v", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message wasn't delivered")
+		}
+	}
+
+	// last local connection of the user is closed
+	err = broker.UnsubscribeUser("1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	waitSubscribers(t, client, "1", 0)
+
+	err = broker.Publish("1", []byte("hello"))
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		t.Errorf("expected no delivery after unsubscribe. Got: %v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_ = broker.SubscribeUser("1")
+	waitSubscribers(t, client, "1", 1)
+	_ = broker.Close()
+
+	err = broker.Publish("1", []byte("hello"))
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		t.Errorf("expected no delivery after close. Got: %v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// redis is down
+	server.Close()
+
+	err = broker.Publish("1", []byte("hello"))
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+// waitSubscribers waits for the subscription of the user channel to change,
+// since it's applied by redis in the background.
+func waitSubscribers(t *testing.T, client *redis.Client, userID string, expected int64) {
+	for i := 0; i < 100; i++ {
+		subscribers, err := client.PubSubNumSub(context.Background(), channel(userID)).Result()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if subscribers[channel(userID)] == expected {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("expected %v subscribers of user %v", expected, userID)
+}
+
+func TestSubscribeError(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("couldn't start redis: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	server.Close()
+
+	err = New(client).Subscribe(func(userID string, msg []byte) error {
+		return nil
+	})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestPresence(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("couldn't start redis: %v", err)
+	}
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	broker := New(client)
+
+	online, lastSeen, err := broker.Presence("1")
+	if online || !lastSeen.IsZero() || err != nil {
+		t.Errorf("expected unknown user to be offline without last seen. Got: %v, %v, %v", online, lastSeen, err)
+	}
+
+	_ = broker.SetOnline("1", "first", time.Minute)
+	_ = broker.SetOnline("1", "second", time.Minute)
+
+	// one device disconnects
+	_ = broker.SetOffline("1", "first")
+
+	online, _, err = broker.Presence("1")
+	if !online || err != nil {
+		t.Errorf("expected user to stay online. Got: %v, %v", online, err)
+	}
+
+	before := time.Now()
+	_ = broker.SetOffline("1", "second")
+
+	online, lastSeen, err = broker.Presence("1")
+	if online || lastSeen.Before(before) || err != nil {
+		t.Errorf("expected user to be offline and last seen at disconnect. Got: %v, %v, %v", online, lastSeen, err)
+	}
+
+	// connection wasn't refreshed in time
+	_ = broker.SetOnline("2", "first", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	online, _, err = broker.Presence("2")
+	if online || err != nil {
+		t.Errorf("expected expired connection to be offline. Got: %v, %v", online, err)
+	}
+
+	// redis is down
+	server.Close()
+
+	err = broker.SetOnline("1", "first", time.Minute)
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	_, _, err = broker.Presence("1")
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestNextStreamID(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("couldn't start redis: %v", err)
+	}
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	// two instances share the counter
+	first := New(client)
+	second := New(client)
+
+	id, err := first.NextStreamID("1")
+	if id != 1 || err != nil {
+		t.Errorf("expected: 1\n got: %v, %v", id, err)
+	}
+
+	id, err = second.NextStreamID("1")
+	if id != 2 || err != nil {
+		t.Errorf("expected: 2\n got: %v, %v", id, err)
+	}
+
+	id, err = second.NextStreamID("2")
+	if id != 1 || err != nil {
+		t.Errorf("expected: 1\n got: %v, %v", id, err)
+	}
+
+	// redis is down
+	server.Close()
+
+	_, err = first.NextStreamID("1")
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
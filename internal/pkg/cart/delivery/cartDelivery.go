@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/cart"
 	"github.com/friends/internal/pkg/middleware"
+	ownErr "github.com/friends/pkg/error"
 	log "github.com/friends/pkg/logger"
 )
 
@@ -43,9 +45,15 @@ func (c CartDelivery) AddToCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cartUsecase.Add(userID, productID[0])
+	quantity, err := getQuantity(r, 1)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = c.cartUsecase.Add(userID, productID[0], quantity)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusConflict)
 		return
 	}
 }
@@ -72,7 +80,13 @@ func (c CartDelivery) RemoveFromCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cartUsecase.Remove(userID, productID[0])
+	quantity, err := getQuantity(r, 0)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = c.cartUsecase.Remove(userID, productID[0], quantity)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -106,3 +120,22 @@ func (c CartDelivery) GetCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// getQuantity reads an optional positive quantity from the query string.
+func getQuantity(r *http.Request, defaultQuantity int) (int, error) {
+	quantityStr := r.URL.Query().Get(configs.Quantity)
+	if quantityStr == "" {
+		return defaultQuantity, nil
+	}
+
+	quantity, err := strconv.Atoi(quantityStr)
+	if err != nil {
+		return 0, fmt.Errorf("wrong quantity: %w", err)
+	}
+
+	if quantity <= 0 {
+		return 0, fmt.Errorf("quantity must be positive, got %v", quantity)
+	}
+
+	return quantity, nil
+}
@@ -26,7 +26,7 @@ func TestAddToCartSuccess(t *testing.T) {
 	userID := "0"
 	productID := "1"
 
-	mockCartUsecase.EXPECT().Add(userID, productID).Times(1).Return(nil)
+	mockCartUsecase.EXPECT().Add(userID, productID, 1).Times(1).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/cart", nil)
@@ -53,7 +53,7 @@ func TestAddToCartError(t *testing.T) {
 	userID := "0"
 	productID := "1"
 
-	mockCartUsecase.EXPECT().Add(userID, productID).Times(1).Return(fmt.Errorf("error"))
+	mockCartUsecase.EXPECT().Add(userID, productID, 1).Times(1).Return(fmt.Errorf("error"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/cart", nil)
@@ -70,6 +70,58 @@ func TestAddToCartError(t *testing.T) {
 	}
 }
 
+func TestAddToCartWithQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartUsecase := cart.NewMockUsecase(ctrl)
+	handler := NewCartDelivery(mockCartUsecase)
+
+	userID := "0"
+	productID := "1"
+
+	mockCartUsecase.EXPECT().Add(userID, productID, 3).Times(1).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/cart", nil)
+	q := r.URL.Query()
+	q.Add(configs.ProductID, productID)
+	q.Add(configs.Quantity, "3")
+	r.URL.RawQuery = q.Encode()
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	handler.AddToCart(w, r.WithContext(ctx))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestAddToCartBadQuantity(t *testing.T) {
+	handler := NewCartDelivery(nil)
+
+	userID := "0"
+	productID := "1"
+
+	for _, quantity := range []string{"0", "-1", "two"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/cart", nil)
+		q := r.URL.Query()
+		q.Add(configs.ProductID, productID)
+		q.Add(configs.Quantity, quantity)
+		r.URL.RawQuery = q.Encode()
+		ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+		handler.AddToCart(w, r.WithContext(ctx))
+
+		expected := http.StatusBadRequest
+		if w.Code != expected {
+			t.Errorf("expected: %v\n got: %v", expected, w.Code)
+		}
+	}
+}
+
 func TestAddToCartNoQueryParam(t *testing.T) {
 	handler := CartDelivery{}
 	userID := "0"
@@ -110,7 +162,7 @@ func TestRemoveFromCartSuccess(t *testing.T) {
 	userID := "0"
 	productID := "1"
 
-	mockCartUsecase.EXPECT().Remove(userID, productID).Times(1).Return(nil)
+	mockCartUsecase.EXPECT().Remove(userID, productID, 0).Times(1).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/cart", nil)
@@ -137,7 +189,7 @@ func TestRemoveFromCartError(t *testing.T) {
 	userID := "0"
 	productID := "1"
 
-	mockCartUsecase.EXPECT().Remove(userID, productID).Times(1).Return(fmt.Errorf("error"))
+	mockCartUsecase.EXPECT().Remove(userID, productID, 0).Times(1).Return(fmt.Errorf("error"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/cart", nil)
@@ -2,13 +2,16 @@ package cart
 
 import (
 	"fmt"
+
+	"github.com/friends/internal/pkg/models"
 )
 
 var ErrCartIsEmpty = fmt.Errorf("cart is empty")
 
+//go:generate mockgen -destination=./repo_mock.go -package=cart github.com/friends/internal/pkg/cart Repository
 type Repository interface {
-	Add(userID, productID, vendorID string) error
-	Remove(userID, productID string) error
-	GetProductIDs(userID string) ([]int, error)
+	Add(userID, productID, vendorID string, quantity int) error
+	Remove(userID, productID string, quantity int) error
+	GetProducts(userID string) ([]models.ProductQuantity, error)
 	GetVendorIDFromCart(userID string) (string, error)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/friends/internal/pkg/models"
 )
 
 func TestAdd(t *testing.T) {
@@ -21,14 +22,15 @@ func TestAdd(t *testing.T) {
 	userID := "0"
 	productID := "1"
 	vendorID := "2"
+	quantity := 3
 
 	// succesful add
 	mock.
 		ExpectExec("INSERT INTO carts").
-		WithArgs(userID, productID, sqlmock.AnyArg()).
+		WithArgs(userID, productID, sqlmock.AnyArg(), quantity).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.Add(userID, productID, vendorID)
+	err = repo.Add(userID, productID, vendorID, quantity)
 	if err != nil {
 		t.Error("unexpected err: %w", err)
 		return
@@ -40,7 +42,7 @@ func TestAdd(t *testing.T) {
 		WithArgs(userID, productID).
 		WillReturnError(fmt.Errorf("db error"))
 
-	err = repo.Add(userID, productID, vendorID)
+	err = repo.Add(userID, productID, vendorID, quantity)
 	if err == nil {
 		t.Error("unexpected error")
 		return
@@ -65,7 +67,7 @@ func TestDelete(t *testing.T) {
 		WithArgs(userID, productID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.Remove(userID, productID)
+	err = repo.Remove(userID, productID, 0)
 	if err != nil {
 		t.Error("unexpected err: %w", err)
 		return
@@ -77,7 +79,57 @@ func TestDelete(t *testing.T) {
 		WithArgs(userID, productID).
 		WillReturnError(fmt.Errorf("db error"))
 
-	err = repo.Remove(userID, productID)
+	err = repo.Remove(userID, productID, 0)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+}
+
+func TestDecrease(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCartRepository(db)
+
+	userID := "0"
+	productID := "1"
+	quantity := 2
+
+	// succesful decrease
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("DELETE FROM carts").
+		WithArgs(userID, productID, quantity).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.
+		ExpectExec("UPDATE carts").
+		WithArgs(userID, productID, quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.Remove(userID, productID, quantity)
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+		return
+	}
+
+	// error with db
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("DELETE FROM carts").
+		WithArgs(userID, productID, quantity).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.
+		ExpectExec("UPDATE carts").
+		WithArgs(userID, productID, quantity).
+		WillReturnError(fmt.Errorf("db error"))
+	mock.ExpectRollback()
+
+	err = repo.Remove(userID, productID, quantity)
 	if err == nil {
 		t.Error("expected error")
 		return
@@ -94,12 +146,15 @@ func TestGet(t *testing.T) {
 	repo := NewCartRepository(db)
 
 	userID := "0"
-	ids := []int{1, 2}
+	products := []models.ProductQuantity{
+		{ProductID: 1, Quantity: 1},
+		{ProductID: 2, Quantity: 3},
+	}
 
 	// good query
-	rows := mock.NewRows([]string{"productID"})
-	for _, id := range ids {
-		rows.AddRow(id)
+	rows := mock.NewRows([]string{"productID", "quantity"})
+	for _, product := range products {
+		rows.AddRow(product.ProductID, product.Quantity)
 	}
 
 	mock.
@@ -107,14 +162,14 @@ func TestGet(t *testing.T) {
 		WithArgs(userID).
 		WillReturnRows(rows)
 
-	resIDs, err := repo.GetProductIDs(userID)
+	resProducts, err := repo.GetProducts(userID)
 	if err != nil {
 		t.Error("unexpected err: %w", err)
 		return
 	}
 
-	if !reflect.DeepEqual(ids, resIDs) {
-		t.Errorf("expected: %v\ngot: %v", ids, resIDs)
+	if !reflect.DeepEqual(products, resProducts) {
+		t.Errorf("expected: %v\ngot: %v", products, resProducts)
 		return
 	}
 
@@ -124,20 +179,20 @@ func TestGet(t *testing.T) {
 		WithArgs(userID).
 		WillReturnError(fmt.Errorf("db error"))
 
-	resIDs, err = repo.GetProductIDs(userID)
+	resProducts, err = repo.GetProducts(userID)
 	if err == nil {
 		t.Error("expected error")
 		return
 	}
 
-	if resIDs != nil {
-		t.Errorf("expected: nil\ngot: %v", resIDs)
+	if resProducts != nil {
+		t.Errorf("expected: nil\ngot: %v", resProducts)
 	}
 
 	// bad query2
-	rows = mock.NewRows([]string{"productID", "vendorID"})
-	for _, id := range ids {
-		rows.AddRow(id, "0")
+	rows = mock.NewRows([]string{"productID", "quantity", "vendorID"})
+	for _, product := range products {
+		rows.AddRow(product.ProductID, product.Quantity, "0")
 	}
 
 	mock.
@@ -145,14 +200,14 @@ func TestGet(t *testing.T) {
 		WithArgs(userID).
 		WillReturnRows(rows)
 
-	resIDs, err = repo.GetProductIDs(userID)
+	resProducts, err = repo.GetProducts(userID)
 	if err == nil {
 		t.Error("expected error")
 		return
 	}
 
-	if resIDs != nil {
-		t.Errorf("expected: nil\ngot: %v", resIDs)
+	if resProducts != nil {
+		t.Errorf("expected: nil\ngot: %v", resProducts)
 	}
 }
 
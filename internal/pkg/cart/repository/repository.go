@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/friends/internal/pkg/cart"
+	"github.com/friends/internal/pkg/models"
 )
 
 type CartRepository struct {
@@ -17,10 +18,11 @@ func NewCartRepository(db *sql.DB) cart.Repository {
 	}
 }
 
-func (c CartRepository) Add(userID, productID, vendorID string) error {
+func (c CartRepository) Add(userID, productID, vendorID string, quantity int) error {
 	_, err := c.db.Exec(
-		"INSERT INTO carts (userID, productID, vendorID) VALUES($1, $2, $3)",
-		userID, productID, vendorID,
+		`INSERT INTO carts (userID, productID, vendorID, quantity) VALUES($1, $2, $3, $4)
+		ON CONFLICT (userID, productID) DO UPDATE SET quantity = carts.quantity + EXCLUDED.quantity`,
+		userID, productID, vendorID, quantity,
 	)
 
 	if err != nil {
@@ -30,42 +32,79 @@ func (c CartRepository) Add(userID, productID, vendorID string) error {
 	return nil
 }
 
-func (c CartRepository) Remove(userID, productID string) error {
-	_, err := c.db.Exec(
-		"DELETE FROM carts WHERE userID=$1 and productID=$2",
-		userID, productID,
+// Remove takes quantity items of the product out of the cart.
+// A non-positive quantity removes the product completely.
+func (c CartRepository) Remove(userID, productID string, quantity int) error {
+	if quantity <= 0 {
+		_, err := c.db.Exec(
+			"DELETE FROM carts WHERE userID=$1 and productID=$2",
+			userID, productID,
+		)
+
+		if err != nil {
+			return fmt.Errorf("couldn't remove product from cart: %w", err)
+		}
+
+		return nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM carts WHERE userID=$1 and productID=$2 and quantity <= $3",
+		userID, productID, quantity,
 	)
 
 	if err != nil {
+		_ = tx.Rollback()
 		return fmt.Errorf("couldn't remove product from cart: %w", err)
 	}
 
+	_, err = tx.Exec(
+		"UPDATE carts SET quantity = quantity - $3 WHERE userID=$1 and productID=$2",
+		userID, productID, quantity,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't decrease product quantity in cart: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-func (c CartRepository) GetProductIDs(userID string) ([]int, error) {
+func (c CartRepository) GetProducts(userID string) ([]models.ProductQuantity, error) {
 	rows, err := c.db.Query(
-		"SELECT productID from carts where userID=$1",
+		"SELECT productID, quantity from carts where userID=$1",
 		userID,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("couldn't get product ids from cart: %w", err)
+		return nil, fmt.Errorf("couldn't get products from cart: %w", err)
 	}
 	defer rows.Close()
 
-	var ids []int
+	var products []models.ProductQuantity
 	for rows.Next() {
-		var id int
-		err = rows.Scan(&id)
+		var product models.ProductQuantity
+		err = rows.Scan(&product.ProductID, &product.Quantity)
 		if err != nil {
-			return nil, fmt.Errorf("error in receiving id: %w", err)
+			return nil, fmt.Errorf("error in receiving product: %w", err)
 		}
 
-		ids = append(ids, id)
+		products = append(products, product)
 	}
 
-	return ids, nil
+	return products, nil
 }
 
 func (c CartRepository) GetVendorIDFromCart(userID string) (string, error) {
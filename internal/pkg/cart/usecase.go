@@ -4,7 +4,7 @@ import "github.com/friends/internal/pkg/models"
 
 //go:generate mockgen -destination=./usecase_mock.go -package=cart github.com/friends/internal/pkg/cart Usecase
 type Usecase interface {
-	Add(userID, productID string) error
-	Remove(userID, productID string) error
+	Add(userID, productID string, quantity int) error
+	Remove(userID, productID string, quantity int) error
 	Get(userID string) ([]models.Product, error)
 }
@@ -3,10 +3,13 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/friends/internal/pkg/cart"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
 )
 
 type CartUsecase struct {
@@ -21,7 +24,7 @@ func NewCartUsecase(cartsRepo cart.Repository, vendorsRepo vendors.Repository) c
 	}
 }
 
-func (c CartUsecase) Add(userID, productID string) error {
+func (c CartUsecase) Add(userID, productID string, quantity int) error {
 	cartVendorID, err := c.cartsRepository.GetVendorIDFromCart(userID)
 	if err != nil && !errors.Is(err, cart.ErrCartIsEmpty) {
 		return fmt.Errorf("error with db: %w", err)
@@ -38,7 +41,21 @@ func (c CartUsecase) Add(userID, productID string) error {
 		}
 	}
 
-	err = c.cartsRepository.Add(userID, productID, vendorID)
+	vendorIDInt, err := strconv.Atoi(vendorID)
+	if err != nil {
+		return fmt.Errorf("wrong vendor id: %w", err)
+	}
+
+	open, err := vendors.IsVendorOpen(c.vendorRepository, vendorIDInt, time.Now())
+	if err != nil {
+		return fmt.Errorf("couldn't check vendor schedule: %w", err)
+	}
+
+	if !open {
+		return ownErr.NewClientError(vendors.ErrVendorClosed)
+	}
+
+	err = c.cartsRepository.Add(userID, productID, vendorID, quantity)
 	if err != nil {
 		return fmt.Errorf("couldn't add product to cart: %w", err)
 	}
@@ -46,14 +63,31 @@ func (c CartUsecase) Add(userID, productID string) error {
 	return nil
 }
 
-func (c CartUsecase) Remove(userID, productID string) error {
-	return c.cartsRepository.Remove(userID, productID)
+func (c CartUsecase) Remove(userID, productID string, quantity int) error {
+	return c.cartsRepository.Remove(userID, productID, quantity)
 }
 
 func (c CartUsecase) Get(userID string) ([]models.Product, error) {
-	ids, err := c.cartsRepository.GetProductIDs(userID)
+	cartProducts, err := c.cartsRepository.GetProducts(userID)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't get product ids: %w", err)
+		return nil, fmt.Errorf("couldn't get products from cart: %w", err)
 	}
-	return c.vendorRepository.GetAllProductsWithIDsFromSameVendor(ids)
+
+	ids := make([]int, 0, len(cartProducts))
+	quantities := make(map[int]int, len(cartProducts))
+	for _, cartProduct := range cartProducts {
+		ids = append(ids, cartProduct.ProductID)
+		quantities[cartProduct.ProductID] = cartProduct.Quantity
+	}
+
+	products, err := c.vendorRepository.GetAllProductsWithIDsFromSameVendor(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range products {
+		products[idx].Quantity = quantities[products[idx].ID]
+	}
+
+	return products, nil
 }
@@ -34,17 +34,17 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 }
 
 // Add mocks base method
-func (m *MockUsecase) Add(arg0, arg1 string) error {
+func (m *MockUsecase) Add(arg0, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret := m.ctrl.Call(m, "Add", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Add indicates an expected call of Add
-func (mr *MockUsecaseMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) Add(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUsecase)(nil).Add), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUsecase)(nil).Add), arg0, arg1, arg2)
 }
 
 // Get mocks base method
@@ -63,15 +63,15 @@ func (mr *MockUsecaseMockRecorder) Get(arg0 interface{}) *gomock.Call {
 }
 
 // Remove mocks base method
-func (m *MockUsecase) Remove(arg0, arg1 string) error {
+func (m *MockUsecase) Remove(arg0, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Remove", arg0, arg1)
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Remove indicates an expected call of Remove
-func (mr *MockUsecaseMockRecorder) Remove(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockUsecase)(nil).Remove), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockUsecase)(nil).Remove), arg0, arg1, arg2)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/friends/internal/pkg/vendors"
 	pool "github.com/friends/internal/pkg/websocketPool"
 	ownErr "github.com/friends/pkg/error"
+	"github.com/friends/pkg/image"
 	log "github.com/friends/pkg/logger"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -69,19 +70,30 @@ func (c ChatDelivery) Upgrade(w http.ResponseWriter, r *http.Request) {
 	}
 	defer ws.Close()
 
-	c.wsPool.Add(userID, ws)
+	err = pool.Prepare(ws)
+	if err != nil {
+		return
+	}
+
+	// The connection works even if the presence isn't saved.
+	err = c.wsPool.Add(userID, ws)
+	if err != nil {
+		log.ErrorLogWithCtx(r.Context(), err)
+	}
 
 	c.read(r.Context(), ws, userID)
 
-	c.wsPool.Delete(userID)
+	err = c.wsPool.Delete(userID, ws)
 }
 
 func (c ChatDelivery) read(ctx context.Context, ws *websocket.Conn, userID string) {
 	for {
 		_, msgJSON, err := ws.ReadMessage()
 		if err != nil {
-			log.ErrorLogWithCtx(ctx, err)
-			continue
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.ErrorLogWithCtx(ctx, err)
+			}
+			return
 		}
 
 		msg := models.Message{}
@@ -90,57 +102,180 @@ func (c ChatDelivery) read(ctx context.Context, ws *websocket.Conn, userID strin
 			continue
 		}
 
+		switch msg.Type {
+		case models.MessageTypeRead:
+			c.markRead(ctx, msg.OrderID, userID)
+			continue
+		case models.MessageTypeTyping, models.MessageTypePresence:
+			c.relay(ctx, msg.Type, msg.OrderID, userID)
+			continue
+		}
+
 		msg.UserID = userID
+		msg.Presence = nil
+		msg.Attachment = ""
 		msg.SentAt = time.Now()
 		msg.Sanitaze()
 
-		err = c.chatUsecase.Save(msg)
+		msg.ID, err = c.chatUsecase.Save(msg)
 		if err != nil {
 			log.ErrorLogWithCtx(ctx, err)
 			return
 		}
 
-		customerID, err := c.orderUsecase.GetUserIDFromOrder(msg.OrderID)
+		customerID, partnerID, vendorID, err := c.participants(msg.OrderID)
 		if err != nil {
 			log.ErrorLogWithCtx(ctx, err)
 			continue
 		}
 
-		vendorID, err := c.orderUsecase.GetVendorIDFromOrder(msg.OrderID)
-		if err != nil {
-			log.ErrorLogWithCtx(ctx, err)
-			continue
-		}
+		c.sendMessage(ctx, msg, customerID, partnerID, vendorID)
+	}
+}
 
-		partnerID, err := c.vendorUsecase.GetVendorOwner(vendorID)
-		if err != nil {
-			log.ErrorLogWithCtx(ctx, err)
-			continue
-		}
+// sendMessage pushes a saved message to the other participant of the chat.
+func (c ChatDelivery) sendMessage(ctx context.Context, msg models.Message, customerID, partnerID string, vendorID int) {
+	msg.Type = models.MessageTypeMessage
+	msg.SentAtStr = msg.SentAt.Format(configs.TimeFormat)
+
+	var recipientID string
+	switch msg.UserID {
+	case customerID:
+		msg.VendorID = vendorID
+		recipientID = partnerID
+	case partnerID:
+		recipientID = customerID
+	default:
+		return
+	}
 
-		msg.Type = "message"
-		msgJSON, err = json.Marshal(msg)
-		if err != nil {
-			log.ErrorLogWithCtx(ctx, err)
-			continue
-		}
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
 
-		if userID == customerID {
-			msg.VendorID = vendorID
-			c.write(ctx, partnerID, msgJSON)
-		} else if userID == partnerID {
-			c.write(ctx, customerID, msgJSON)
-		}
+	c.write(ctx, recipientID, msgJSON)
+}
+
+func (c ChatDelivery) participants(orderID int) (customerID string, partnerID string, vendorID int, err error) {
+	customerID, err = c.orderUsecase.GetUserIDFromOrder(orderID)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	vendorID, err = c.orderUsecase.GetVendorIDFromOrder(orderID)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	partnerID, err = c.vendorUsecase.GetVendorOwner(vendorID)
+	if err != nil {
+		return "", "", 0, err
 	}
+
+	return customerID, partnerID, vendorID, nil
 }
 
-func (c ChatDelivery) write(ctx context.Context, userID string, text []byte) {
-	conn, ok := c.wsPool.Get(userID)
-	if !ok {
+// markRead saves the read receipt of the user and sends it to both
+// participants, so the sender sees the message read and the other devices
+// of the reader update their unread counters.
+func (c ChatDelivery) markRead(ctx context.Context, orderID int, userID string) {
+	customerID, partnerID, _, err := c.participants(orderID)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
+
+	if userID != customerID && userID != partnerID {
+		log.ErrorLogWithCtx(ctx, fmt.Errorf("user %v isn't in chat %v", userID, orderID))
 		return
 	}
 
-	err := conn.WriteMessage(websocket.TextMessage, text)
+	receipt := models.Message{
+		Type:    models.MessageTypeRead,
+		OrderID: orderID,
+		UserID:  userID,
+		ReadAt:  time.Now(),
+	}
+	receipt.ReadAtStr = receipt.ReadAt.Format(configs.TimeFormat)
+
+	err = c.chatUsecase.MarkRead(orderID, userID, receipt.ReadAt)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
+
+	receiptJSON, err := json.Marshal(receipt)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
+
+	c.write(ctx, customerID, receiptJSON)
+	c.write(ctx, partnerID, receiptJSON)
+}
+
+// relay passes a typing or presence event to the other participant of the
+// chat. These events aren't saved.
+func (c ChatDelivery) relay(ctx context.Context, eventType string, orderID int, userID string) {
+	customerID, partnerID, vendorID, err := c.participants(orderID)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
+
+	event := models.Message{
+		Type:    eventType,
+		OrderID: orderID,
+	}
+	if eventType == models.MessageTypePresence {
+		presence := c.presence(ctx, userID)
+		event.Presence = &presence
+	}
+
+	var recipientID string
+	switch userID {
+	case customerID:
+		event.VendorID = vendorID
+		recipientID = partnerID
+	case partnerID:
+		recipientID = customerID
+	default:
+		log.ErrorLogWithCtx(ctx, fmt.Errorf("user %v isn't in chat %v", userID, orderID))
+		return
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+		return
+	}
+
+	c.write(ctx, recipientID, eventJSON)
+}
+
+// presence is an extra for the chat, the user is shown offline
+// when it can't be got.
+func (c ChatDelivery) presence(ctx context.Context, userID string) models.Presence {
+	online, lastSeen, err := c.wsPool.Presence(userID)
+	if err != nil {
+		log.ErrorLogWithCtx(ctx, err)
+	}
+
+	presence := models.Presence{
+		Online:   online,
+		LastSeen: lastSeen,
+	}
+	if !lastSeen.IsZero() {
+		presence.LastSeenStr = lastSeen.Format(configs.TimeFormat)
+	}
+
+	return presence
+}
+
+func (c ChatDelivery) write(ctx context.Context, userID string, text []byte) {
+	err := c.wsPool.Send(userID, text)
 	if err != nil {
 		log.ErrorLogWithCtx(ctx, err)
 	}
@@ -174,40 +309,103 @@ func (c ChatDelivery) GetChat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := parseCursor(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	userIDFromDB, err := c.orderUsecase.GetUserIDFromOrder(orderID)
 	if err != nil {
 		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
 		return
 	}
 
-	var vendorID int
+	vendorID, err := c.orderUsecase.GetVendorIDFromOrder(orderID)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	interlocutorID := userIDFromDB
 	if userID != userIDFromDB {
-		vendorID, err = c.orderUsecase.GetVendorIDFromOrder(orderID)
+		err = c.vendorUsecase.CheckVendorOwner(userID, strconv.Itoa(vendorID))
 		if err != nil {
-			ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-
-		err = c.vendorUsecase.CheckVendorOwner(userID, strconv.Itoa(vendorID))
+	} else {
+		interlocutorID, err = c.vendorUsecase.GetVendorOwner(vendorID)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	}
 
-	msgs, err := c.chatUsecase.GetChat(orderID, userID)
+	history, err := c.chatUsecase.GetChat(orderID, userID, cursor)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	presence := c.presence(r.Context(), interlocutorID)
+
+	// the response stays an array of messages, the rest goes in headers
+	w.Header().Set("Access-Control-Expose-Headers",
+		configs.HasMoreHeader+", "+configs.InterlocutorOnlineHeader+", "+configs.InterlocutorLastSeenHeader)
+	w.Header().Set(configs.HasMoreHeader, strconv.FormatBool(history.HasMore))
+	w.Header().Set(configs.InterlocutorOnlineHeader, strconv.FormatBool(presence.Online))
+	if presence.LastSeenStr != "" {
+		w.Header().Set(configs.InterlocutorLastSeenHeader, presence.LastSeenStr)
+	}
 
-	err = json.NewEncoder(w).Encode(msgs)
+	err = json.NewEncoder(w).Encode(history.Messages)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
+func parseCursor(r *http.Request) (models.ChatCursor, error) {
+	cursor := models.ChatCursor{Limit: configs.ChatLimit}
+
+	var err error
+	if limitQueryParam, ok := r.URL.Query()[configs.Limit]; ok {
+		cursor.Limit, err = strconv.Atoi(limitQueryParam[0])
+		if err != nil {
+			return models.ChatCursor{}, err
+		}
+		if cursor.Limit <= 0 || cursor.Limit > configs.MaxChatLimit {
+			return models.ChatCursor{}, fmt.Errorf("wrong limit: %v", cursor.Limit)
+		}
+	}
+
+	if beforeQueryParam, ok := r.URL.Query()[configs.Before]; ok {
+		cursor.Before, err = strconv.Atoi(beforeQueryParam[0])
+		if err != nil {
+			return models.ChatCursor{}, err
+		}
+		if cursor.Before <= 0 {
+			return models.ChatCursor{}, fmt.Errorf("wrong message id: %v", cursor.Before)
+		}
+	}
+
+	if afterQueryParam, ok := r.URL.Query()[configs.After]; ok {
+		cursor.After, err = strconv.Atoi(afterQueryParam[0])
+		if err != nil {
+			return models.ChatCursor{}, err
+		}
+		if cursor.After <= 0 {
+			return models.ChatCursor{}, fmt.Errorf("wrong message id: %v", cursor.After)
+		}
+	}
+
+	if cursor.Before != 0 && cursor.After != 0 {
+		return models.ChatCursor{}, fmt.Errorf("both before and after are set")
+	}
+
+	return cursor, nil
+}
+
 func (c ChatDelivery) GetVendorChats(w http.ResponseWriter, r *http.Request) {
 	var err error
 	defer func() {
@@ -236,15 +434,144 @@ func (c ChatDelivery) GetVendorChats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chats, err := c.chatUsecase.GetVendorChats(vendorID)
+	chats, err := c.chatUsecase.GetVendorChats(vendorID, userID)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	for idx := range chats {
+		chats[idx].Presence = c.presence(r.Context(), chats[idx].InterlocutorID)
+	}
+
 	err = json.NewEncoder(w).Encode(chats)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
+
+// GetUnreadCount returns the number of unread messages in the chats of all
+// the partner's vendors.
+func (c ChatDelivery) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	unread, err := c.chatUsecase.GetUnreadCount(userID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(models.UnreadResponse{Unread: unread})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// SendAttachment uploads an image to the chat and sends it as a message
+// with an optional text.
+func (c ChatDelivery) SendAttachment(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	orderIDStr, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no id in url")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	orderID, err := strconv.Atoi(orderIDStr)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	customerID, partnerID, vendorID, err := c.participants(orderID)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if userID != customerID && userID != partnerID {
+		err = fmt.Errorf("user %v isn't in chat %v", userID, orderID)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = r.ParseMultipartForm(configs.ImgMaxSize)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile(configs.AttachmentFormFileKey)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	mimeType := header.Header.Get("Content-Type")
+	imageType, err := image.CheckMimeType(mimeType)
+	if err != nil {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	attachment, err := c.chatUsecase.UploadAttachment(file, imageType)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	msg := models.Message{
+		OrderID:    orderID,
+		UserID:     userID,
+		Text:       r.FormValue(configs.AttachmentTextKey),
+		Attachment: attachment,
+		SentAt:     time.Now(),
+	}
+	msg.Sanitaze()
+
+	msg.ID, err = c.chatUsecase.Save(msg)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	c.sendMessage(r.Context(), msg, customerID, partnerID, vendorID)
+
+	msg.Type = models.MessageTypeMessage
+	msg.IsYourMsg = true
+	msg.SentAtStr = msg.SentAt.Format(configs.TimeFormat)
+	err = json.NewEncoder(w).Encode(msg)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
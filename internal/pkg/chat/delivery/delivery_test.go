@@ -1,17 +1,22 @@
 package delivery
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker/memory"
 	"github.com/friends/internal/pkg/chat"
 	"github.com/friends/internal/pkg/middleware"
 	"github.com/friends/internal/pkg/models"
@@ -20,6 +25,7 @@ import (
 	websocketpool "github.com/friends/internal/pkg/websocketPool"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 )
 
 var (
@@ -60,7 +66,7 @@ var (
 
 	dbError = fmt.Errorf("db error")
 
-	wsPool = websocketpool.NewWebsocketPool()
+	wsPool = websocketpool.NewWebsocketPool(memory.New())
 )
 
 func TestGetChatSuccess(t *testing.T) {
@@ -69,19 +75,19 @@ func TestGetChatSuccess(t *testing.T) {
 
 	mockChatUsecase := chat.NewMockUsecase(ctrl)
 	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 
 	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
-	mockChatUsecase.EXPECT().GetChat(orderID, userID).Times(1).Return(testMsgs, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockChatUsecase.EXPECT().GetChat(orderID, userID, models.ChatCursor{Limit: configs.ChatLimit}).Times(1).Return(models.ChatHistory{Messages: testMsgs}, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/chats", nil)
 	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(orderID)})
 	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
 
-	handler := ChatDelivery{
-		chatUsecase:  mockChatUsecase,
-		orderUsecase: mockOrderUsecase,
-	}
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, wsPool)
 
 	handler.GetChat(w, r.WithContext(ctx))
 
@@ -95,6 +101,10 @@ func TestGetChatSuccess(t *testing.T) {
 	if !reflect.DeepEqual(testMsgs, msgs) {
 		t.Errorf("expected: %v\n got: %v", testMsgs, msgs)
 	}
+
+	if w.Header().Get(configs.HasMoreHeader) != "false" || w.Header().Get(configs.InterlocutorOnlineHeader) != "false" {
+		t.Errorf("expected no more messages and offline interlocutor. Got: %v", w.Header())
+	}
 }
 
 func TestGetChatSuccess2(t *testing.T) {
@@ -108,7 +118,7 @@ func TestGetChatSuccess2(t *testing.T) {
 	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
 	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
 	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, strconv.Itoa(vendorID)).Times(1).Return(nil)
-	mockChatUsecase.EXPECT().GetChat(orderID, partnerID).Times(1).Return(testMsgs, nil)
+	mockChatUsecase.EXPECT().GetChat(orderID, partnerID, models.ChatCursor{Limit: configs.ChatLimit}).Times(1).Return(models.ChatHistory{Messages: testMsgs}, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/chats", nil)
@@ -129,6 +139,10 @@ func TestGetChatSuccess2(t *testing.T) {
 	if !reflect.DeepEqual(testMsgs, msgs) {
 		t.Errorf("expected: %v\n got: %v", testMsgs, msgs)
 	}
+
+	if w.Header().Get(configs.HasMoreHeader) != "false" || w.Header().Get(configs.InterlocutorOnlineHeader) != "false" {
+		t.Errorf("expected no more messages and offline interlocutor. Got: %v", w.Header())
+	}
 }
 
 func TestGetChatCheckVendorError(t *testing.T) {
@@ -195,18 +209,19 @@ func TestGetChatGetChatError(t *testing.T) {
 	mockChatUsecase := chat.NewMockUsecase(ctrl)
 	mockOrderUsecase := order.NewMockUsecase(ctrl)
 
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
 	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
-	mockChatUsecase.EXPECT().GetChat(orderID, userID).Times(1).Return(nil, dbError)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockChatUsecase.EXPECT().GetChat(orderID, userID, models.ChatCursor{Limit: configs.ChatLimit}).Times(1).Return(models.ChatHistory{}, dbError)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/chats", nil)
 	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(orderID)})
 	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
 
-	handler := ChatDelivery{
-		chatUsecase:  mockChatUsecase,
-		orderUsecase: mockOrderUsecase,
-	}
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, wsPool)
 
 	handler.GetChat(w, r.WithContext(ctx))
 
@@ -294,7 +309,7 @@ func TestGetVendorChats(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 
 	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, strconv.Itoa(vendorID)).Times(1).Return(nil)
-	mockChatUsecase.EXPECT().GetVendorChats(strconv.Itoa(vendorID)).Times(1).Return(testChats, nil)
+	mockChatUsecase.EXPECT().GetVendorChats(strconv.Itoa(vendorID), partnerID).Times(1).Return(testChats, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/chats", nil)
@@ -304,6 +319,7 @@ func TestGetVendorChats(t *testing.T) {
 	handler := ChatDelivery{
 		chatUsecase:   mockChatUsecase,
 		vendorUsecase: mockVendorUsecase,
+		wsPool:        wsPool,
 	}
 
 	handler.GetVendorChats(w, r.WithContext(ctx))
@@ -328,7 +344,7 @@ func TestGetVendorChatsError(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 
 	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, strconv.Itoa(vendorID)).Times(1).Return(nil)
-	mockChatUsecase.EXPECT().GetVendorChats(strconv.Itoa(vendorID)).Times(1).Return(nil, dbError)
+	mockChatUsecase.EXPECT().GetVendorChats(strconv.Itoa(vendorID), partnerID).Times(1).Return(nil, dbError)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/chats", nil)
@@ -401,3 +417,376 @@ func TestGetVendorChatsNoUser(t *testing.T) {
 		t.Errorf("expected: %v\n got: %v", expected, w.Code)
 	}
 }
+
+func TestUpgradeExitsOnClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool := websocketpool.NewWebsocketPool(memory.New())
+	handler := New(chat.NewMockUsecase(ctrl), order.NewMockUsecase(ctrl), vendors.NewMockUsecase(ctrl), pool)
+
+	finished := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+		handler.Upgrade(w, r.WithContext(ctx))
+		close(finished)
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+
+	err = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	conn.Close()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("expected the handler to return after the close")
+	}
+
+	if isOnline(pool, userID) {
+		t.Errorf("expected the connection to be removed from the pool")
+	}
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockChatUsecase.EXPECT().GetUnreadCount(partnerID).Times(1).Return(4, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/partners/chats/unread", nil)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler := New(mockChatUsecase, nil, nil, wsPool)
+	handler.GetUnreadCount(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+
+	expected := models.UnreadResponse{Unread: 4}
+	var resp models.UnreadResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !reflect.DeepEqual(expected, resp) {
+		t.Errorf("expected: %v\n got: %v", expected, resp)
+	}
+}
+
+func TestGetUnreadCountError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockChatUsecase.EXPECT().GetUnreadCount(partnerID).Times(1).Return(0, dbError)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/partners/chats/unread", nil)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler := New(mockChatUsecase, nil, nil, wsPool)
+	handler.GetUnreadCount(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusInternalServerError
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func TestReadReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockChatUsecase.EXPECT().MarkRead(orderID, partnerID, gomock.Any()).Times(1).Return(nil)
+
+	pool := websocketpool.NewWebsocketPool(memory.New())
+	_ = pool.Listen()
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, pool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), r.URL.Query().Get("user"))
+		handler.Upgrade(w, r.WithContext(ctx))
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?user="
+	customerConn, _, err := websocket.DefaultDialer.Dial(url+userID, nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+	defer customerConn.Close()
+	partnerConn, _, err := websocket.DefaultDialer.Dial(url+partnerID, nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+	defer partnerConn.Close()
+
+	// wait for both connections to join the pool
+	for !isOnline(pool, userID) || !isOnline(pool, partnerID) {
+		time.Sleep(time.Millisecond)
+	}
+
+	err = partnerConn.WriteMessage(websocket.TextMessage, []byte(`{"type":"read","order_id":50}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, conn := range []*websocket.Conn{customerConn, partnerConn} {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		_, msgJSON, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		receipt := models.Message{}
+		_ = json.Unmarshal(msgJSON, &receipt)
+		if receipt.Type != models.MessageTypeRead || receipt.OrderID != orderID || receipt.ReadAtStr == "" {
+			t.Errorf("expected read receipt. Got: %s", msgJSON)
+		}
+	}
+}
+
+func TestTypingRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(2).Return(userID, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(2).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(2).Return(partnerID, nil)
+
+	pool := websocketpool.NewWebsocketPool(memory.New())
+	_ = pool.Listen()
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, pool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), r.URL.Query().Get("user"))
+		handler.Upgrade(w, r.WithContext(ctx))
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?user="
+	customerConn, _, err := websocket.DefaultDialer.Dial(url+userID, nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+	defer customerConn.Close()
+	partnerConn, _, err := websocket.DefaultDialer.Dial(url+partnerID, nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+	defer partnerConn.Close()
+
+	for !isOnline(pool, userID) || !isOnline(pool, partnerID) {
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, eventType := range []string{models.MessageTypeTyping, models.MessageTypePresence} {
+		err = customerConn.WriteMessage(websocket.TextMessage, []byte(`{"type":"`+eventType+`","order_id":50}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_ = partnerConn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		_, eventJSON, err := partnerConn.ReadMessage()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		event := models.Message{}
+		_ = json.Unmarshal(eventJSON, &event)
+		if event.Type != eventType || event.OrderID != orderID || event.VendorID != vendorID {
+			t.Errorf("expected %v event. Got: %s", eventType, eventJSON)
+		}
+
+		if eventType == models.MessageTypePresence && (event.Presence == nil || !event.Presence.Online) {
+			t.Errorf("expected customer to be online. Got: %s", eventJSON)
+		}
+	}
+}
+
+func TestGetChatWrongCursor(t *testing.T) {
+	for _, query := range []string{"?before=1&after=2", "?limit=0", "?limit=1000", "?before=-1", "?after=abc"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/chats"+query, nil)
+		r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(orderID)})
+		ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+		handler := ChatDelivery{}
+
+		handler.GetChat(w, r.WithContext(ctx))
+
+		expected := http.StatusBadRequest
+		if w.Code != expected {
+			t.Errorf("%v: expected: %v\n got: %v", query, expected, w.Code)
+		}
+	}
+}
+
+func attachmentRequest(t *testing.T, contentType string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="attachment"; filename="dish.jpg"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = part.Write([]byte("image"))
+	_ = writer.WriteField(configs.AttachmentTextKey, "wrong dish")
+	_ = writer.Close()
+
+	r := httptest.NewRequest("POST", "/chats/50/attachments", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(orderID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	return r.WithContext(ctx)
+}
+
+func TestSendAttachmentSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockChatUsecase.EXPECT().UploadAttachment(gomock.Any(), ".jpg").Times(1).Return("dish.jpg", nil)
+	mockChatUsecase.EXPECT().Save(gomock.Any()).Times(1).DoAndReturn(func(msg models.Message) (int, error) {
+		if msg.Attachment != "dish.jpg" || msg.Text != "wrong dish" || msg.UserID != userID {
+			t.Errorf("unexpected message: %v", msg)
+		}
+		return 7, nil
+	})
+
+	w := httptest.NewRecorder()
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, wsPool)
+
+	handler.SendAttachment(w, attachmentRequest(t, "image/jpeg"))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+
+	var msg models.Message
+	_ = json.Unmarshal(w.Body.Bytes(), &msg)
+	if msg.ID != 7 || msg.Attachment != "dish.jpg" || msg.Type != models.MessageTypeMessage || !msg.IsYourMsg {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+}
+
+func TestSendAttachmentWrongType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+
+	w := httptest.NewRecorder()
+	handler := New(nil, mockOrderUsecase, mockVendorUsecase, wsPool)
+
+	handler.SendAttachment(w, attachmentRequest(t, "application/pdf"))
+
+	expected := http.StatusUnsupportedMediaType
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestSendAttachmentNotInChat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return("1", nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+
+	w := httptest.NewRecorder()
+	handler := New(nil, mockOrderUsecase, mockVendorUsecase, wsPool)
+
+	handler.SendAttachment(w, attachmentRequest(t, "image/jpeg"))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func isOnline(pool websocketpool.WebsocketPool, userID string) bool {
+	online, _, _ := pool.Presence(userID)
+	return online
+}
+
+func TestGetChatHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatUsecase := chat.NewMockUsecase(ctrl)
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	broker := memory.New()
+	_ = broker.SetOnline(partnerID, "1", time.Minute)
+
+	cursor := models.ChatCursor{Before: 3, Limit: 2}
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(orderID).Times(1).Return(userID, nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(orderID).Times(1).Return(vendorID, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockChatUsecase.EXPECT().GetChat(orderID, userID, cursor).Times(1).Return(models.ChatHistory{Messages: testMsgs, HasMore: true}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/chats?before=3&limit=2", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(orderID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	handler := New(mockChatUsecase, mockOrderUsecase, mockVendorUsecase, websocketpool.NewWebsocketPool(broker))
+
+	handler.GetChat(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected: %v\n got: %v", http.StatusOK, w.Code)
+	}
+
+	if w.Header().Get(configs.HasMoreHeader) != "true" || w.Header().Get(configs.InterlocutorOnlineHeader) != "true" {
+		t.Errorf("expected more messages and online interlocutor. Got: %v", w.Header())
+	}
+
+	var msgs []models.Message
+	err := json.Unmarshal(w.Body.Bytes(), &msgs)
+	if err != nil || len(msgs) != len(testMsgs) {
+		t.Errorf("expected an array of messages. Got: %v", w.Body.String())
+	}
+}
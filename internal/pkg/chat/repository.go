@@ -1,9 +1,17 @@
 package chat
 
-import "github.com/friends/internal/pkg/models"
+import (
+	"time"
 
+	"github.com/friends/internal/pkg/models"
+)
+
+//go:generate mockgen -destination=./repo_mock.go -package=chat github.com/friends/internal/pkg/chat Repository
 type Repository interface {
-	Save(models.Message) error
-	GetChat(orderID int) ([]models.Message, error)
-	GetVendorChats(orderIDs []int) ([]models.Chat, error)
+	Save(models.Message) (int, error)
+	GetChat(orderID int, cursor models.ChatCursor) ([]models.Message, error)
+	GetVendorChats(vendorID string, userID string) ([]models.Chat, error)
+	MarkRead(orderID int, userID string, readAt time.Time) error
+	GetReadMarkers(orderID int) (map[string]time.Time, error)
+	GetUnreadCount(partnerID string) (int, error)
 }
@@ -7,17 +7,20 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/models"
 )
 
 var fatalError = "an error '%w' was not expected when opening a stub database connection"
 
 var testMsg = models.Message{
-	OrderID:   0,
-	UserID:    "0",
-	Text:      "test",
-	SentAt:    time.Date(2020, 4, 10, 12, 42, 19, 58, time.Local),
-	SentAtStr: "10.04.2020 12:42:19",
+	ID:         1,
+	OrderID:    0,
+	UserID:     "0",
+	Text:       "test",
+	Attachment: "photo.jpg",
+	SentAt:     time.Date(2020, 4, 10, 12, 42, 19, 58, time.Local),
+	SentAtStr:  "10.04.2020 12:42:19",
 }
 
 var dbError = fmt.Errorf("db error")
@@ -33,23 +36,27 @@ func TestSave(t *testing.T) {
 
 	// good query
 	mock.
-		ExpectExec("INSERT").
-		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.Text, testMsg.SentAt).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		ExpectQuery("INSERT").
+		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.Text, testMsg.Attachment, testMsg.SentAt).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(testMsg.ID))
+
+	id, err := repo.Save(testMsg)
 
-	err = repo.Save(testMsg)
+	if id != testMsg.ID {
+		t.Errorf("expected: %v\n got: %v", testMsg.ID, id)
+	}
 
 	if err != nil {
-		t.Errorf("unexpected error: %w", err)
+		t.Errorf("unexpected error: %v", err)
 	}
 
 	// bad query
 	mock.
-		ExpectExec("INSERT").
-		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.Text, testMsg.SentAt).
+		ExpectQuery("INSERT").
+		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.Text, testMsg.Attachment, testMsg.SentAt).
 		WillReturnError(dbError)
 
-	err = repo.Save(testMsg)
+	_, err = repo.Save(testMsg)
 
 	if err == nil {
 		t.Errorf("expected error. Got nil")
@@ -65,36 +72,70 @@ func TestGetChat(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"userID", "message_text", "sent_at"})
-	for i := 0; i < 2; i++ {
-		rows.AddRow(testMsg.UserID, testMsg.Text, testMsg.SentAt)
+	newerMsg := testMsg
+	newerMsg.ID = 2
+	newerMsg.Text = "test2"
+
+	// latest page comes newest first from db
+	mock.
+		ExpectQuery("ORDER BY sent_at DESC, id DESC").
+		WithArgs(testMsg.OrderID, 10).
+		WillReturnRows(mock.NewRows([]string{"id", "userID", "message_text", "attachment", "sent_at"}).
+			AddRow(newerMsg.ID, newerMsg.UserID, newerMsg.Text, newerMsg.Attachment, newerMsg.SentAt).
+			AddRow(testMsg.ID, testMsg.UserID, testMsg.Text, testMsg.Attachment, testMsg.SentAt))
+
+	msgs, err := repo.GetChat(testMsg.OrderID, models.ChatCursor{Limit: 10})
+
+	expected := []models.Message{testMsg, newerMsg}
+	if !reflect.DeepEqual(expected, msgs) {
+		t.Errorf("expected: %v\n got: %v", expected, msgs)
 	}
 
-	// good query
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// before a message
 	mock.
-		ExpectQuery("SELECT").
-		WithArgs(testMsg.OrderID).
-		WillReturnRows(rows)
+		ExpectQuery("\\(sent_at, id\\) <").
+		WithArgs(testMsg.OrderID, newerMsg.ID, 10).
+		WillReturnRows(mock.NewRows([]string{"id", "userID", "message_text", "attachment", "sent_at"}).
+			AddRow(testMsg.ID, testMsg.UserID, testMsg.Text, testMsg.Attachment, testMsg.SentAt))
 
-	msgs, err := repo.GetChat(testMsg.OrderID)
+	msgs, err = repo.GetChat(testMsg.OrderID, models.ChatCursor{Before: newerMsg.ID, Limit: 10})
 
-	for _, msg := range msgs {
-		if !reflect.DeepEqual(testMsg, msg) {
-			t.Errorf("expected: %v\n got: %v", testMsg, msg)
-		}
+	if !reflect.DeepEqual([]models.Message{testMsg}, msgs) {
+		t.Errorf("expected: %v\n got: %v", []models.Message{testMsg}, msgs)
 	}
 
 	if err != nil {
-		t.Errorf("unexpected error: %w", err)
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// after a message comes oldest first
+	mock.
+		ExpectQuery("\\(sent_at, id\\) >").
+		WithArgs(testMsg.OrderID, testMsg.ID, 10).
+		WillReturnRows(mock.NewRows([]string{"id", "userID", "message_text", "attachment", "sent_at"}).
+			AddRow(newerMsg.ID, newerMsg.UserID, newerMsg.Text, newerMsg.Attachment, newerMsg.SentAt))
+
+	msgs, err = repo.GetChat(testMsg.OrderID, models.ChatCursor{After: testMsg.ID, Limit: 10})
+
+	if !reflect.DeepEqual([]models.Message{newerMsg}, msgs) {
+		t.Errorf("expected: %v\n got: %v", []models.Message{newerMsg}, msgs)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
 	}
 
 	// bad query
 	mock.
 		ExpectQuery("SELECT").
-		WithArgs(testMsg.OrderID).
+		WithArgs(testMsg.OrderID, 10).
 		WillReturnError(dbError)
 
-	msgs, err = repo.GetChat(testMsg.OrderID)
+	msgs, err = repo.GetChat(testMsg.OrderID, models.ChatCursor{Limit: 10})
 
 	if msgs != nil {
 		t.Errorf("expected: %v\n got: %v", nil, msgs)
@@ -105,17 +146,12 @@ func TestGetChat(t *testing.T) {
 	}
 
 	// bad query 2
-	rows = mock.NewRows([]string{"userID"})
-	for i := 0; i < 2; i++ {
-		rows.AddRow(testMsg.UserID)
-	}
-
 	mock.
 		ExpectQuery("SELECT").
-		WithArgs(testMsg.OrderID).
-		WillReturnRows(rows)
+		WithArgs(testMsg.OrderID, 10).
+		WillReturnRows(mock.NewRows([]string{"userID"}).AddRow(testMsg.UserID))
 
-	msgs, err = repo.GetChat(testMsg.OrderID)
+	msgs, err = repo.GetChat(testMsg.OrderID, models.ChatCursor{Limit: 10})
 
 	if msgs != nil {
 		t.Errorf("expected: %v\n got: %v", nil, msgs)
@@ -135,42 +171,56 @@ func TestGetVendorChats(t *testing.T) {
 
 	repo := New(db)
 
-	chat := models.Chat{
-		OrderID:        0,
-		InterlocutorID: "0",
-		LastMsg:        "test",
+	newer := models.Chat{
+		OrderID:          2,
+		InterlocutorID:   "5",
+		InterlocutorName: "customer",
+		LastMsg:          "where is my order?",
+		LastMsgAt:        testMsg.SentAt.Add(time.Hour),
+		LastMsgAtStr:     testMsg.SentAt.Add(time.Hour).Format(configs.TimeFormat),
+		UnreadCount:      3,
+	}
+	older := models.Chat{
+		OrderID:        1,
+		InterlocutorID: "6",
+		LastAttachment: "dish.jpg",
+		LastMsgAt:      testMsg.SentAt,
+		LastMsgAtStr:   testMsg.SentAt.Format(configs.TimeFormat),
 	}
 
-	rows := mock.NewRows([]string{"orderID", "userID", "message_text"})
-	for i := 0; i < 2; i++ {
-		rows.AddRow(chat.OrderID, chat.InterlocutorID, chat.LastMsg)
+	columns := []string{"id", "userID", "username", "message_text", "attachment", "sent_at", "count"}
+	rows := mock.NewRows(columns)
+	for _, chat := range []models.Chat{newer, older} {
+		rows.AddRow(
+			chat.OrderID, chat.InterlocutorID, chat.InterlocutorName,
+			chat.LastMsg, chat.LastAttachment, chat.LastMsgAt, chat.UnreadCount,
+		)
 	}
 
 	// good query
 	mock.
-		ExpectQuery("SELECT").
-		WithArgs(sqlmock.AnyArg()).
+		ExpectQuery("SELECT o.id, o.userID").
+		WithArgs("10", "1").
 		WillReturnRows(rows)
 
-	chats, err := repo.GetVendorChats([]int{0, 1})
+	chats, err := repo.GetVendorChats("10", "1")
 
-	for _, c := range chats {
-		if !reflect.DeepEqual(c, chat) {
-			t.Errorf("expected: %v\n got: %v", chat, c)
-		}
+	expected := []models.Chat{newer, older}
+	if !reflect.DeepEqual(expected, chats) {
+		t.Errorf("expected: %v\n got: %v", expected, chats)
 	}
 
 	if err != nil {
-		t.Errorf("unexpected error: %w", err)
+		t.Errorf("unexpected error: %v", err)
 	}
 
 	// bad query
 	mock.
-		ExpectQuery("SELECT").
-		WithArgs(sqlmock.AnyArg()).
+		ExpectQuery("SELECT o.id, o.userID").
+		WithArgs("10", "1").
 		WillReturnError(dbError)
 
-	chats, err = repo.GetVendorChats([]int{0, 1})
+	chats, err = repo.GetVendorChats("10", "1")
 
 	if chats != nil {
 		t.Errorf("expected: %v\n got: %v", nil, chats)
@@ -181,17 +231,12 @@ func TestGetVendorChats(t *testing.T) {
 	}
 
 	// bad query 2
-	rows = mock.NewRows([]string{"orderID"})
-	for i := 0; i < 2; i++ {
-		rows.AddRow(chat.OrderID)
-	}
-
 	mock.
-		ExpectQuery("SELECT").
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(rows)
+		ExpectQuery("SELECT o.id, o.userID").
+		WithArgs("10", "1").
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
 
-	chats, err = repo.GetVendorChats([]int{0, 1})
+	chats, err = repo.GetVendorChats("10", "1")
 
 	if chats != nil {
 		t.Errorf("expected: %v\n got: %v", nil, chats)
@@ -201,3 +246,114 @@ func TestGetVendorChats(t *testing.T) {
 		t.Errorf("expected error. Got nil")
 	}
 }
+
+func TestMarkRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectExec("INSERT INTO chat_reads").
+		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.SentAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.MarkRead(testMsg.OrderID, testMsg.UserID, testMsg.SentAt)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("INSERT INTO chat_reads").
+		WithArgs(testMsg.OrderID, testMsg.UserID, testMsg.SentAt).
+		WillReturnError(dbError)
+
+	err = repo.MarkRead(testMsg.OrderID, testMsg.UserID, testMsg.SentAt)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetReadMarkers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectQuery("SELECT userID, read_at FROM chat_reads").
+		WithArgs(testMsg.OrderID).
+		WillReturnRows(mock.NewRows([]string{"userID", "read_at"}).AddRow("1", testMsg.SentAt))
+
+	markers, err := repo.GetReadMarkers(testMsg.OrderID)
+
+	expected := map[string]time.Time{"1": testMsg.SentAt}
+	if !reflect.DeepEqual(expected, markers) {
+		t.Errorf("expected: %v\n got: %v", expected, markers)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT userID, read_at FROM chat_reads").
+		WithArgs(testMsg.OrderID).
+		WillReturnError(dbError)
+
+	_, err = repo.GetReadMarkers(testMsg.OrderID)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectQuery("SELECT COUNT").
+		WithArgs("1").
+		WillReturnRows(mock.NewRows([]string{"count"}).AddRow(7))
+
+	unread, err := repo.GetUnreadCount("1")
+
+	if unread != 7 {
+		t.Errorf("expected: %v\n got: %v", 7, unread)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT COUNT").
+		WithArgs("1").
+		WillReturnError(dbError)
+
+	_, err = repo.GetUnreadCount("1")
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
@@ -3,11 +3,11 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/chat"
 	"github.com/friends/internal/pkg/models"
-	"github.com/lib/pq"
 )
 
 type ChatRepository struct {
@@ -20,25 +20,55 @@ func New(db *sql.DB) chat.Repository {
 	}
 }
 
-func (c ChatRepository) Save(msg models.Message) error {
-	_, err := c.db.Exec(
-		"INSERT INTO messages (orderID, userID, message_text, sent_at) VALUES ($1, $2, $3, $4)",
-		msg.OrderID, msg.UserID, msg.Text, msg.SentAt,
-	)
+func (c ChatRepository) Save(msg models.Message) (int, error) {
+	var id int
+	err := c.db.QueryRow(
+		`INSERT INTO messages (orderID, userID, message_text, attachment, sent_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		msg.OrderID, msg.UserID, msg.Text, msg.Attachment, msg.SentAt,
+	).Scan(&id)
 
 	if err != nil {
-		return fmt.Errorf("couldn't insert message on order %v from user with id %v. Error: %w", msg.OrderID, msg.UserID, err)
+		return 0, fmt.Errorf(
+			"couldn't insert message on order %v from user with id %v. Error: %w", msg.OrderID, msg.UserID, err,
+		)
 	}
 
-	return nil
+	return id, nil
 }
 
-func (c ChatRepository) GetChat(orderID int) ([]models.Message, error) {
-	rows, err := c.db.Query(
-		"SELECT userID, message_text, sent_at FROM messages WHERE orderID = $1",
-		orderID,
+// GetChat returns a page of the chat ordered by the time the messages were
+// sent. Messages sent at the same time are ordered by id.
+func (c ChatRepository) GetChat(orderID int, cursor models.ChatCursor) ([]models.Message, error) {
+	var (
+		rows *sql.Rows
+		err  error
 	)
 
+	switch {
+	case cursor.After != 0:
+		rows, err = c.db.Query(
+			`SELECT id, userID, message_text, attachment, sent_at FROM messages
+			WHERE orderID = $1 AND (sent_at, id) > (SELECT sent_at, id FROM messages WHERE id = $2 AND orderID = $1)
+			ORDER BY sent_at, id LIMIT $3`,
+			orderID, cursor.After, cursor.Limit,
+		)
+	case cursor.Before != 0:
+		rows, err = c.db.Query(
+			`SELECT id, userID, message_text, attachment, sent_at FROM messages
+			WHERE orderID = $1 AND (sent_at, id) < (SELECT sent_at, id FROM messages WHERE id = $2 AND orderID = $1)
+			ORDER BY sent_at DESC, id DESC LIMIT $3`,
+			orderID, cursor.Before, cursor.Limit,
+		)
+	default:
+		rows, err = c.db.Query(
+			`SELECT id, userID, message_text, attachment, sent_at FROM messages
+			WHERE orderID = $1
+			ORDER BY sent_at DESC, id DESC LIMIT $2`,
+			orderID, cursor.Limit,
+		)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get messages for order id %v. Error: %w", orderID, err)
 	}
@@ -47,23 +77,44 @@ func (c ChatRepository) GetChat(orderID int) ([]models.Message, error) {
 	msgs := make([]models.Message, 0)
 	var msg models.Message
 	for rows.Next() {
-		err = rows.Scan(&msg.UserID, &msg.Text, &msg.SentAt)
+		err = rows.Scan(&msg.ID, &msg.UserID, &msg.Text, &msg.Attachment, &msg.SentAt)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get msg for order id %v. Error: %w", orderID, err)
 		}
+		msg.OrderID = orderID
 		msg.SentAtStr = msg.SentAt.Format(configs.TimeFormat)
 
 		msgs = append(msgs, msg)
 	}
 
+	// pages going back in time are read from the newest message
+	if cursor.After == 0 {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
+
 	return msgs, nil
 }
 
-func (c ChatRepository) GetVendorChats(orderIDs []int) ([]models.Chat, error) {
+// GetVendorChats returns the chats of the vendor's orders, most recent
+// first. Every chat has its last message, the customer's name and the
+// number of messages the user hasn't read yet.
+func (c ChatRepository) GetVendorChats(vendorID string, userID string) ([]models.Chat, error) {
 	rows, err := c.db.Query(
-		`SELECT orderID, userID, message_text FROM messages
-		WHERE orderID = ANY ($1) AND sent_at IN (SELECT MAX(sent_at) FROM messages GROUP BY orderID)`,
-		pq.Array(orderIDs),
+		`SELECT o.id, o.userID, COALESCE(p.username, ''), last.message_text, last.attachment, last.sent_at,
+		(SELECT COUNT(*) FROM messages AS u
+		WHERE u.orderID = o.id AND u.userID != $2 AND (r.read_at IS NULL OR u.sent_at > r.read_at))
+		FROM orders AS o
+		JOIN LATERAL (
+			SELECT message_text, attachment, sent_at FROM messages
+			WHERE orderID = o.id ORDER BY sent_at DESC, id DESC LIMIT 1
+		) AS last ON true
+		LEFT JOIN profiles AS p ON p.userID = o.userID
+		LEFT JOIN chat_reads AS r ON r.orderID = o.id AND r.userID = $2
+		WHERE o.vendorID = $1
+		ORDER BY last.sent_at DESC, o.id DESC`,
+		vendorID, userID,
 	)
 
 	if err != nil {
@@ -74,13 +125,83 @@ func (c ChatRepository) GetVendorChats(orderIDs []int) ([]models.Chat, error) {
 	chats := make([]models.Chat, 0)
 	chat := models.Chat{}
 	for rows.Next() {
-		err = rows.Scan(&chat.OrderID, &chat.InterlocutorID, &chat.LastMsg)
+		err = rows.Scan(
+			&chat.OrderID, &chat.InterlocutorID, &chat.InterlocutorName,
+			&chat.LastMsg, &chat.LastAttachment, &chat.LastMsgAt, &chat.UnreadCount,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get chat. Error: %w", err)
 		}
+		chat.LastMsgAtStr = chat.LastMsgAt.Format(configs.TimeFormat)
 
 		chats = append(chats, chat)
 	}
 
 	return chats, nil
 }
+
+// MarkRead moves the read marker of the user in the chat forward. The
+// marker never goes back, so a late receipt can't unread messages.
+func (c ChatRepository) MarkRead(orderID int, userID string, readAt time.Time) error {
+	_, err := c.db.Exec(
+		`INSERT INTO chat_reads (orderID, userID, read_at) VALUES ($1, $2, $3)
+		ON CONFLICT (orderID, userID) DO UPDATE SET read_at = GREATEST(chat_reads.read_at, EXCLUDED.read_at)`,
+		orderID, userID, readAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("couldn't mark chat %v read for user %v. Error: %w", orderID, userID, err)
+	}
+
+	return nil
+}
+
+// GetReadMarkers returns until when each participant has read the chat.
+func (c ChatRepository) GetReadMarkers(orderID int) (map[string]time.Time, error) {
+	rows, err := c.db.Query(
+		"SELECT userID, read_at FROM chat_reads WHERE orderID = $1",
+		orderID,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get read markers for order id %v. Error: %w", orderID, err)
+	}
+	defer rows.Close()
+
+	markers := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			userID string
+			readAt time.Time
+		)
+
+		err = rows.Scan(&userID, &readAt)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get read marker for order id %v. Error: %w", orderID, err)
+		}
+
+		markers[userID] = readAt
+	}
+
+	return markers, nil
+}
+
+// GetUnreadCount returns the number of unread messages in the chats of all
+// the vendors of the partner.
+func (c ChatRepository) GetUnreadCount(partnerID string) (int, error) {
+	var unread int
+	err := c.db.QueryRow(
+		`SELECT COUNT(*) FROM messages AS m
+		JOIN orders AS o ON o.id = m.orderID
+		JOIN vendor_partner AS vp ON vp.vendorID = o.vendorID
+		LEFT JOIN chat_reads AS r ON r.orderID = m.orderID AND r.userID = $1
+		WHERE vp.partnerID = $1 AND m.userID != $1 AND (r.read_at IS NULL OR m.sent_at > r.read_at)`,
+		partnerID,
+	).Scan(&unread)
+
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get unread count for partner %v. Error: %w", partnerID, err)
+	}
+
+	return unread, nil
+}
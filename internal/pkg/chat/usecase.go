@@ -1,10 +1,18 @@
 package chat
 
-import "github.com/friends/internal/pkg/models"
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/friends/internal/pkg/models"
+)
 
 //go:generate mockgen -destination=./usecase_mock.go -package=chat github.com/friends/internal/pkg/chat Usecase
 type Usecase interface {
-	Save(models.Message) error
-	GetChat(orderID int, userID string) ([]models.Message, error)
-	GetVendorChats(vendorID string) ([]models.Chat, error)
+	Save(models.Message) (int, error)
+	GetChat(orderID int, userID string, cursor models.ChatCursor) (models.ChatHistory, error)
+	GetVendorChats(vendorID string, userID string) ([]models.Chat, error)
+	MarkRead(orderID int, userID string, readAt time.Time) error
+	GetUnreadCount(partnerID string) (int, error)
+	UploadAttachment(file multipart.File, fileType string) (string, error)
 }
@@ -1,36 +1,57 @@
 package usecase
 
 import (
+	"fmt"
+	"mime/multipart"
+	"time"
+
 	"github.com/friends/internal/pkg/chat"
+	"github.com/friends/internal/pkg/fileserver"
 	"github.com/friends/internal/pkg/models"
-	"github.com/friends/internal/pkg/order"
-	"github.com/friends/internal/pkg/profile"
+	ownErr "github.com/friends/pkg/error"
 )
 
 type ChatUsecase struct {
-	chatRepository    chat.Repository
-	profileRepository profile.Repository
-	orderRepository   order.Repository
+	chatRepository chat.Repository
+	fsClient       fileserver.UploadServiceClient
 }
 
-func New(
-	chatRepository chat.Repository, profileRepository profile.Repository, orderRepository order.Repository,
-) chat.Usecase {
+func New(chatRepository chat.Repository, fileserverClient fileserver.UploadServiceClient) chat.Usecase {
 	return ChatUsecase{
-		chatRepository:    chatRepository,
-		profileRepository: profileRepository,
-		orderRepository:   orderRepository,
+		chatRepository: chatRepository,
+		fsClient:       fileserverClient,
 	}
 }
 
-func (c ChatUsecase) Save(msg models.Message) error {
+func (c ChatUsecase) Save(msg models.Message) (int, error) {
 	return c.chatRepository.Save(msg)
 }
 
-func (c ChatUsecase) GetChat(orderID int, userID string) ([]models.Message, error) {
-	msgs, err := c.chatRepository.GetChat(orderID)
+// GetChat returns a page of the chat. One message more than the limit is
+// fetched to tell whether there are more.
+func (c ChatUsecase) GetChat(orderID int, userID string, cursor models.ChatCursor) (models.ChatHistory, error) {
+	limit := cursor.Limit
+	cursor.Limit++
+
+	msgs, err := c.chatRepository.GetChat(orderID, cursor)
 	if err != nil {
-		return nil, err
+		return models.ChatHistory{}, err
+	}
+
+	history := models.ChatHistory{}
+	if len(msgs) > limit {
+		history.HasMore = true
+		// the extra message is the farthest from the cursor
+		if cursor.After != 0 {
+			msgs = msgs[:limit]
+		} else {
+			msgs = msgs[1:]
+		}
+	}
+
+	markers, err := c.chatRepository.GetReadMarkers(orderID)
+	if err != nil {
+		return models.ChatHistory{}, err
 	}
 
 	for idx := range msgs {
@@ -39,30 +60,45 @@ func (c ChatUsecase) GetChat(orderID int, userID string) ([]models.Message, erro
 		} else {
 			msgs[idx].IsYourMsg = false
 		}
+
+		msgs[idx].IsRead = isRead(msgs[idx], markers)
 	}
+	history.Messages = msgs
 
-	return msgs, nil
+	return history, nil
 }
 
-func (c ChatUsecase) GetVendorChats(vendorID string) ([]models.Chat, error) {
-	orderIDs, err := c.orderRepository.GetVendorOrdersIDs(vendorID)
-	if err != nil {
-		return nil, err
+// isRead reports whether the message was read by the other participant,
+// that is anyone who didn't send it.
+func isRead(msg models.Message, markers map[string]time.Time) bool {
+	for readerID, readAt := range markers {
+		if readerID != msg.UserID && !msg.SentAt.After(readAt) {
+			return true
+		}
 	}
 
-	chats, err := c.chatRepository.GetVendorChats(orderIDs)
-	if err != nil {
-		return nil, err
-	}
+	return false
+}
 
-	for idx := range chats {
-		name, err := c.profileRepository.GetUsername(chats[idx].InterlocutorID)
-		if err != nil {
-			return nil, err
-		}
+func (c ChatUsecase) GetVendorChats(vendorID string, userID string) ([]models.Chat, error) {
+	return c.chatRepository.GetVendorChats(vendorID, userID)
+}
 
-		chats[idx].InterlocutorName = name
+func (c ChatUsecase) MarkRead(orderID int, userID string, readAt time.Time) error {
+	return c.chatRepository.MarkRead(orderID, userID, readAt)
+}
+
+func (c ChatUsecase) GetUnreadCount(partnerID string) (int, error) {
+	return c.chatRepository.GetUnreadCount(partnerID)
+}
+
+// UploadAttachment streams the file to the file server and returns the name
+// it's stored under.
+func (c ChatUsecase) UploadAttachment(file multipart.File, fileType string) (string, error) {
+	fileName, err := fileserver.Upload(c.fsClient, file, fileType)
+	if err != nil {
+		return "", ownErr.NewServerError(fmt.Errorf("couldn't upload attachment: %w", err))
 	}
 
-	return chats, nil
+	return fileName, nil
 }
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/friends/internal/pkg/chat"
+	"github.com/friends/internal/pkg/models"
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	orderID    = 50
+	customerID = "10"
+	partnerID  = "11"
+
+	sentAt = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	dbError = fmt.Errorf("db error")
+)
+
+func TestGetChatReadState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatRepo := chat.NewMockRepository(ctrl)
+	usecase := New(mockChatRepo, nil)
+
+	msgs := []models.Message{
+		{UserID: customerID, Text: "read by partner", SentAt: sentAt},
+		{UserID: customerID, Text: "not read yet", SentAt: sentAt.Add(time.Minute * 2)},
+		{UserID: partnerID, Text: "read by customer", SentAt: sentAt.Add(time.Minute * 3)},
+	}
+
+	mockChatRepo.EXPECT().GetChat(orderID, models.ChatCursor{Limit: 4}).Times(1).Return(msgs, nil)
+	mockChatRepo.EXPECT().GetReadMarkers(orderID).Times(1).Return(map[string]time.Time{
+		partnerID:  sentAt.Add(time.Minute),
+		customerID: sentAt.Add(time.Minute * 5),
+	}, nil)
+
+	history, err := usecase.GetChat(orderID, customerID, models.ChatCursor{Limit: 3})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if history.HasMore {
+		t.Errorf("expected no more messages")
+	}
+	chatMsgs := history.Messages
+
+	expected := []bool{true, false, true}
+	for idx, msg := range chatMsgs {
+		if msg.IsRead != expected[idx] {
+			t.Errorf("%v: expected read: %v\n got: %v", msg.Text, expected[idx], msg.IsRead)
+		}
+	}
+
+	if !chatMsgs[0].IsYourMsg || chatMsgs[2].IsYourMsg {
+		t.Errorf("expected own messages to be marked")
+	}
+
+	// db error
+	mockChatRepo.EXPECT().GetChat(orderID, models.ChatCursor{Limit: 4}).Times(1).Return(msgs, nil)
+	mockChatRepo.EXPECT().GetReadMarkers(orderID).Times(1).Return(nil, dbError)
+
+	_, err = usecase.GetChat(orderID, customerID, models.ChatCursor{Limit: 3})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetChatHasMore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChatRepo := chat.NewMockRepository(ctrl)
+	usecase := New(mockChatRepo, nil)
+
+	msgs := []models.Message{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	// going back the extra message is the oldest
+	mockChatRepo.EXPECT().GetChat(orderID, models.ChatCursor{Before: 4, Limit: 3}).Times(1).Return(msgs, nil)
+	mockChatRepo.EXPECT().GetReadMarkers(orderID).Times(1).Return(map[string]time.Time{}, nil)
+
+	history, err := usecase.GetChat(orderID, customerID, models.ChatCursor{Before: 4, Limit: 2})
+
+	if err != nil || !history.HasMore || len(history.Messages) != 2 || history.Messages[0].ID != 2 {
+		t.Errorf("expected messages 2 and 3 with more. Got: %v, %v", history, err)
+	}
+
+	// going forward the extra message is the newest
+	msgs = []models.Message{{ID: 2}, {ID: 3}, {ID: 4}}
+	mockChatRepo.EXPECT().GetChat(orderID, models.ChatCursor{After: 1, Limit: 3}).Times(1).Return(msgs, nil)
+	mockChatRepo.EXPECT().GetReadMarkers(orderID).Times(1).Return(map[string]time.Time{}, nil)
+
+	history, err = usecase.GetChat(orderID, customerID, models.ChatCursor{After: 1, Limit: 2})
+
+	if err != nil || !history.HasMore || len(history.Messages) != 2 || history.Messages[1].ID != 3 {
+		t.Errorf("expected messages 2 and 3 with more. Got: %v, %v", history, err)
+	}
+}
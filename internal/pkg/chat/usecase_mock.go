@@ -7,7 +7,9 @@ package chat
 import (
 	models "github.com/friends/internal/pkg/models"
 	gomock "github.com/golang/mock/gomock"
+	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 )
 
 // MockUsecase is a mock of Usecase interface
@@ -34,45 +36,90 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 }
 
 // GetChat mocks base method
-func (m *MockUsecase) GetChat(arg0 int, arg1 string) ([]models.Message, error) {
+func (m *MockUsecase) GetChat(arg0 int, arg1 string, arg2 models.ChatCursor) (models.ChatHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChat", arg0, arg1)
-	ret0, _ := ret[0].([]models.Message)
+	ret := m.ctrl.Call(m, "GetChat", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.ChatHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetChat indicates an expected call of GetChat
-func (mr *MockUsecaseMockRecorder) GetChat(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) GetChat(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChat", reflect.TypeOf((*MockUsecase)(nil).GetChat), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChat", reflect.TypeOf((*MockUsecase)(nil).GetChat), arg0, arg1, arg2)
+}
+
+// GetUnreadCount mocks base method
+func (m *MockUsecase) GetUnreadCount(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount
+func (mr *MockUsecaseMockRecorder) GetUnreadCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockUsecase)(nil).GetUnreadCount), arg0)
 }
 
 // GetVendorChats mocks base method
-func (m *MockUsecase) GetVendorChats(arg0 string) ([]models.Chat, error) {
+func (m *MockUsecase) GetVendorChats(arg0, arg1 string) ([]models.Chat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVendorChats", arg0)
+	ret := m.ctrl.Call(m, "GetVendorChats", arg0, arg1)
 	ret0, _ := ret[0].([]models.Chat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVendorChats indicates an expected call of GetVendorChats
-func (mr *MockUsecaseMockRecorder) GetVendorChats(arg0 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) GetVendorChats(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorChats", reflect.TypeOf((*MockUsecase)(nil).GetVendorChats), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorChats", reflect.TypeOf((*MockUsecase)(nil).GetVendorChats), arg0, arg1)
 }
 
-// Save mocks base method
-func (m *MockUsecase) Save(arg0 models.Message) error {
+// MarkRead mocks base method
+func (m *MockUsecase) MarkRead(arg0 int, arg1 string, arg2 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0)
+	ret := m.ctrl.Call(m, "MarkRead", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// MarkRead indicates an expected call of MarkRead
+func (mr *MockUsecaseMockRecorder) MarkRead(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockUsecase)(nil).MarkRead), arg0, arg1, arg2)
+}
+
+// Save mocks base method
+func (m *MockUsecase) Save(arg0 models.Message) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Save indicates an expected call of Save
 func (mr *MockUsecaseMockRecorder) Save(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUsecase)(nil).Save), arg0)
 }
+
+// UploadAttachment mocks base method
+func (m *MockUsecase) UploadAttachment(arg0 multipart.File, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment
+func (mr *MockUsecaseMockRecorder) UploadAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockUsecase)(nil).UploadAttachment), arg0, arg1)
+}
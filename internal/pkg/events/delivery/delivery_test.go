@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker/memory"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/middleware"
+	"github.com/friends/internal/pkg/models"
+	websocketpool "github.com/friends/internal/pkg/websocketPool"
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	userID = "2"
+
+	testEvents = []models.Event{
+		{
+			ID:           5,
+			Type:         models.EventNewOrder,
+			Order:        &models.OrderResponse{ID: 10, Products: []models.OrderProduct{}},
+			CreatedAtStr: "12:00 02.01.2026",
+		},
+	}
+)
+
+func TestGetEventsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventsUsecase := events.NewMockUsecase(ctrl)
+	mockEventsUsecase.EXPECT().GetAfter(userID, 4).Times(1).Return(testEvents, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/partners/events?after=4", nil)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	handler := New(mockEventsUsecase, websocketpool.NewWebsocketPool(memory.New()))
+	handler.GetEvents(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+
+	var resp []models.Event
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !reflect.DeepEqual(testEvents, resp) {
+		t.Errorf("expected: %v\n got: %v", testEvents, resp)
+	}
+}
+
+func TestGetEventsWrongAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventsUsecase := events.NewMockUsecase(ctrl)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/partners/events?after=abc", nil)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	handler := New(mockEventsUsecase, websocketpool.NewWebsocketPool(memory.New()))
+	handler.GetEvents(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusBadRequest
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func streamEvents(t *testing.T, wsPool websocketpool.WebsocketPool, lastEventID string, send func()) string {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/events", nil)
+	if lastEventID != "" {
+		r.Header.Set(configs.LastEventIDHeader, lastEventID)
+	}
+	ctx, cancel := context.WithCancel(context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID))
+
+	handler := New(nil, wsPool)
+
+	finished := make(chan struct{})
+	go func() {
+		handler.Stream(w, r.WithContext(ctx))
+		close(finished)
+	}()
+
+	// wait for the stream to subscribe
+	time.Sleep(time.Millisecond * 50)
+	send()
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	<-finished
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected: %v\n got: %v", http.StatusOK, w.Code)
+	}
+
+	return w.Body.String()
+}
+
+func TestStreamResume(t *testing.T) {
+	wsPool := websocketpool.NewWebsocketPool(memory.New())
+	_ = wsPool.Listen()
+
+	firstBody := streamEvents(t, wsPool, "", func() {
+		_ = wsPool.Send(userID, []byte(`{"type":"status","order_id":"1"}`))
+	})
+
+	if !strings.Contains(firstBody, "event: status\ndata: {\"type\":\"status\",\"order_id\":\"1\"}\n\n") {
+		t.Fatalf("expected status event. Got: %q", firstBody)
+	}
+	lastEventID := strings.TrimPrefix(strings.Split(firstBody, "\n")[0], "id: ")
+
+	// missed while disconnected
+	_ = wsPool.Send(userID, []byte(`{"type":"message","text":"hi"}`))
+
+	secondBody := streamEvents(t, wsPool, lastEventID, func() {
+		_ = wsPool.Send(userID, []byte(`{"type":"new_order"}`))
+	})
+
+	if strings.Contains(secondBody, "event: status") {
+		t.Errorf("expected no events before Last-Event-ID. Got: %q", secondBody)
+	}
+	missed := strings.Index(secondBody, "event: message")
+	live := strings.Index(secondBody, "event: new_order")
+	if missed == -1 || live == -1 || missed > live {
+		t.Errorf("expected missed message then new_order. Got: %q", secondBody)
+	}
+}
+
+func TestStreamWrongLastEventID(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/events", nil)
+	r.Header.Set(configs.LastEventIDHeader, "abc")
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), userID)
+
+	handler := New(nil, websocketpool.NewWebsocketPool(memory.New()))
+	handler.Stream(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusBadRequest
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
@@ -0,0 +1,163 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/middleware"
+	pool "github.com/friends/internal/pkg/websocketPool"
+	ownErr "github.com/friends/pkg/error"
+	log "github.com/friends/pkg/logger"
+)
+
+type EventsDelivery struct {
+	eventsUsecase events.Usecase
+	wsPool        pool.WebsocketPool
+}
+
+func New(eventsUsecase events.Usecase, wsPool pool.WebsocketPool) EventsDelivery {
+	return EventsDelivery{
+		eventsUsecase: eventsUsecase,
+		wsPool:        wsPool,
+	}
+}
+
+// GetEvents returns the saved events following the one with the given id.
+// The id is the one in the event itself, not the id of the event stream.
+func (e EventsDelivery) GetEvents(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	afterID := 0
+	afterQueryParam, ok := r.URL.Query()[configs.After]
+	if ok {
+		afterID, err = strconv.Atoi(afterQueryParam[0])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	eventList, err := e.eventsUsecase.GetAfter(userID, afterID)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(eventList)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// Stream sends the websocket events of the user as Server-Sent Events for
+// clients that can't open a websocket. A reconnecting client gets the events
+// it missed after Last-Event-ID while they are retained. The stream has ids
+// of its own: a saved event carries its id in the data, and only that one
+// can be passed to GetEvents.
+func (e EventsDelivery) Stream(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err = fmt.Errorf("streaming isn't supported")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var lastStreamID int64
+	if lastStreamIDHeader := r.Header.Get(configs.LastEventIDHeader); lastStreamIDHeader != "" {
+		lastStreamID, err = strconv.ParseInt(lastStreamIDHeader, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	eventChan, backlog, cancel, err := e.wsPool.Subscribe(userID, lastStreamID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range backlog {
+		err = writeEvent(w, event)
+		if err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(configs.EventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-eventChan:
+			if !ok {
+				return
+			}
+			err = writeEvent(w, event)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+		case <-r.Context().Done():
+			return
+		}
+
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent names the event after the type of the websocket message, so
+// the stream has the same status, message and new_order events.
+func writeEvent(w http.ResponseWriter, event pool.Event) error {
+	message := struct {
+		Type string `json:"type"`
+	}{}
+	err := json.Unmarshal(event.Data, &message)
+	if err != nil {
+		return fmt.Errorf("couldn't decode event %v: %w", event.StreamID, err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.StreamID, message.Type, event.Data)
+	return err
+}
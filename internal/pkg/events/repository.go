@@ -0,0 +1,9 @@
+package events
+
+import "github.com/friends/internal/pkg/models"
+
+//go:generate mockgen -destination=./repo_mock.go -package=events github.com/friends/internal/pkg/events Repository
+type Repository interface {
+	Add(userID string, event models.Event) (models.Event, error)
+	GetAfter(userID string, afterID int, limit int) ([]models.Event, error)
+}
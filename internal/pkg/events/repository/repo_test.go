@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/models"
+)
+
+const fatalError = "an error '%s' was not expected when opening a stub database connection"
+
+var (
+	userID  = "1"
+	dbError = fmt.Errorf("db error")
+)
+
+func TestAdd(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	event := models.Event{
+		Type:      models.EventNewOrder,
+		Order:     &models.OrderResponse{ID: 10, Products: []models.OrderProduct{}},
+		CreatedAt: time.Now(),
+	}
+	payload, _ := event.MarshalJSON()
+
+	// good query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("DELETE FROM events").
+		WithArgs(userID, event.CreatedAt.Add(-configs.EventsTTL)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.
+		ExpectQuery("INSERT INTO events").
+		WithArgs(userID, event.Type, string(payload), event.CreatedAt).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectCommit()
+
+	saved, err := repo.Add(userID, event)
+
+	expected := event
+	expected.ID = 5
+	expected.CreatedAtStr = event.CreatedAt.Format(configs.TimeFormat)
+	if !reflect.DeepEqual(expected, saved) {
+		t.Errorf("expected: %v\n got: %v", expected, saved)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("DELETE FROM events").
+		WithArgs(userID, event.CreatedAt.Add(-configs.EventsTTL)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.
+		ExpectQuery("INSERT INTO events").
+		WithArgs(userID, event.Type, string(payload), event.CreatedAt).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	_, err = repo.Add(userID, event)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	// old events aren't deleted
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("DELETE FROM events").
+		WithArgs(userID, event.CreatedAt.Add(-configs.EventsTTL)).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	_, err = repo.Add(userID, event)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	createdAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	event := models.Event{
+		ID:           5,
+		Type:         models.EventNewOrder,
+		Order:        &models.OrderResponse{ID: 10, Products: []models.OrderProduct{}},
+		CreatedAt:    createdAt,
+		CreatedAtStr: createdAt.Format(configs.TimeFormat),
+	}
+	payload, _ := models.Event{Type: event.Type, Order: event.Order}.MarshalJSON()
+
+	// good query
+	mock.
+		ExpectQuery("SELECT id, payload, created_at FROM events").
+		WithArgs(userID, 4, 10).
+		WillReturnRows(mock.NewRows([]string{"id", "payload", "created_at"}).AddRow(5, string(payload), createdAt))
+
+	eventList, err := repo.GetAfter(userID, 4, 10)
+
+	if !reflect.DeepEqual([]models.Event{event}, eventList) {
+		t.Errorf("expected: %v\n got: %v", []models.Event{event}, eventList)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// broken payload
+	mock.
+		ExpectQuery("SELECT id, payload, created_at FROM events").
+		WithArgs(userID, 4, 10).
+		WillReturnRows(mock.NewRows([]string{"id", "payload", "created_at"}).AddRow(5, "{", createdAt))
+
+	_, err = repo.GetAfter(userID, 4, 10)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT id, payload, created_at FROM events").
+		WithArgs(userID, 4, 10).
+		WillReturnError(dbError)
+
+	_, err = repo.GetAfter(userID, 4, 10)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
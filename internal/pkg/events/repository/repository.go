@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/models"
+	ownErr "github.com/friends/pkg/error"
+)
+
+type EventsRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) events.Repository {
+	return EventsRepository{
+		db: db,
+	}
+}
+
+// Add saves the event for the user and returns it with the assigned id.
+// The events of the user older than configs.EventsTTL are deleted, nobody
+// resumes a stream after that long.
+func (e EventsRepository) Add(userID string, event models.Event) (models.Event, error) {
+	payload, err := event.MarshalJSON()
+	if err != nil {
+		return models.Event{}, ownErr.NewServerError(fmt.Errorf("couldn't encode event: %w", err))
+	}
+
+	tx, err := e.db.Begin()
+	if err != nil {
+		return models.Event{}, ownErr.NewServerError(fmt.Errorf("couldn't create transaction: %w", err))
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM events WHERE userID = $1 AND created_at < $2",
+		userID, event.CreatedAt.Add(-configs.EventsTTL),
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Event{}, ownErr.NewServerError(fmt.Errorf("couldn't delete old events: %w", err))
+	}
+
+	err = tx.QueryRow(
+		"INSERT INTO events (userID, event_type, payload, created_at) VALUES ($1, $2, $3, $4) RETURNING id",
+		userID, event.Type, string(payload), event.CreatedAt,
+	).Scan(&event.ID)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Event{}, ownErr.NewServerError(fmt.Errorf("couldn't insert event: %w", err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Event{}, ownErr.NewServerError(fmt.Errorf("couldn't commit transaction: %w", err))
+	}
+
+	event.CreatedAtStr = event.CreatedAt.Format(configs.TimeFormat)
+
+	return event, nil
+}
+
+// GetAfter returns the events of the user following the given event id
+// in the order they happened.
+func (e EventsRepository) GetAfter(userID string, afterID int, limit int) ([]models.Event, error) {
+	rows, err := e.db.Query(
+		"SELECT id, payload, created_at FROM events WHERE userID = $1 AND id > $2 ORDER BY id LIMIT $3",
+		userID, afterID, limit,
+	)
+
+	if err != nil {
+		return nil, ownErr.NewServerError(fmt.Errorf("couldn't get events: %w", err))
+	}
+	defer rows.Close()
+
+	eventList := make([]models.Event, 0)
+	for rows.Next() {
+		var (
+			event   models.Event
+			id      int
+			payload string
+		)
+
+		err = rows.Scan(&id, &payload, &event.CreatedAt)
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get event: %w", err))
+		}
+
+		err = event.UnmarshalJSON([]byte(payload))
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't decode event %v: %w", id, err))
+		}
+
+		event.ID = id
+		event.CreatedAtStr = event.CreatedAt.Format(configs.TimeFormat)
+		eventList = append(eventList, event)
+	}
+
+	return eventList, nil
+}
@@ -0,0 +1,9 @@
+package events
+
+import "github.com/friends/internal/pkg/models"
+
+//go:generate mockgen -destination=./usecase_mock.go -package=events github.com/friends/internal/pkg/events Usecase
+type Usecase interface {
+	Publish(userID string, event models.Event) error
+	GetAfter(userID string, afterID int) ([]models.Event, error)
+}
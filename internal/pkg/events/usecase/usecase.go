@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/models"
+	websocketpool "github.com/friends/internal/pkg/websocketPool"
+	ownErr "github.com/friends/pkg/error"
+)
+
+type EventsUsecase struct {
+	repository    events.Repository
+	websocketPool websocketpool.WebsocketPool
+}
+
+func New(repository events.Repository, websocketPool websocketpool.WebsocketPool) events.Usecase {
+	return EventsUsecase{
+		repository:    repository,
+		websocketPool: websocketPool,
+	}
+}
+
+// Publish saves the event so it can be fetched after a reconnect and sends
+// it to the user's websocket if the user is online.
+func (e EventsUsecase) Publish(userID string, event models.Event) error {
+	event.CreatedAt = time.Now()
+
+	event, err := e.repository.Add(userID, event)
+	if err != nil {
+		return err
+	}
+
+	msgJSON, err := event.MarshalJSON()
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't encode event: %w", err))
+	}
+
+	err = e.websocketPool.Send(userID, msgJSON)
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't send event: %w", err))
+	}
+
+	return nil
+}
+
+func (e EventsUsecase) GetAfter(userID string, afterID int) ([]models.Event, error) {
+	if afterID < 0 {
+		return nil, ownErr.NewClientError(fmt.Errorf("wrong event id: %v", afterID))
+	}
+
+	return e.repository.GetAfter(userID, afterID, configs.EventsLimit)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker/memory"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/models"
+	websocketpool "github.com/friends/internal/pkg/websocketPool"
+	ownErr "github.com/friends/pkg/error"
+	"github.com/golang/mock/gomock"
+)
+
+var userID = "2"
+
+func TestPublishOffline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := events.NewMockRepository(ctrl)
+	usecase := New(mockRepo, websocketpool.NewWebsocketPool(memory.New()))
+
+	event := models.Event{Type: models.EventNewOrder, Order: &models.OrderResponse{ID: 10}}
+
+	mockRepo.EXPECT().Add(userID, gomock.Any()).Times(1).DoAndReturn(
+		func(userID string, saved models.Event) (models.Event, error) {
+			if saved.CreatedAt.IsZero() {
+				t.Errorf("expected creation time to be set")
+			}
+			saved.ID = 1
+			return saved, nil
+		})
+
+	err := usecase.Publish(userID, event)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := events.NewMockRepository(ctrl)
+	usecase := New(mockRepo, websocketpool.NewWebsocketPool(memory.New()))
+
+	mockRepo.EXPECT().GetAfter(userID, 4, configs.EventsLimit).Times(1).Return([]models.Event{}, nil)
+
+	_, err := usecase.GetAfter(userID, 4)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// negative id
+	_, err = usecase.GetAfter(userID, -1)
+
+	if reqErr, ok := err.(ownErr.RequestError); !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package fileserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lithammer/shortuuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const chunkSize = 1024
+
+// Upload streams the file to the file server under a new random name with
+// the given extension and returns the name.
+func Upload(client UploadServiceClient, file io.Reader, fileType string) (string, error) {
+	fileName := shortuuid.New() + fileType
+
+	md := metadata.New(map[string]string{"fileName": fileName})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	stream, err := client.Upload(ctx)
+	if err != nil {
+		return "", fmt.Errorf("couldn't start upload: %w", err)
+	}
+
+	chunk := make([]byte, chunkSize)
+	for {
+		size, readErr := file.Read(chunk)
+		if size > 0 {
+			err = stream.Send(&Chunk{Content: chunk[:size]})
+			if err != nil {
+				return "", fmt.Errorf("couldn't send chunk: %w", err)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			return "", fmt.Errorf("couldn't read file: %w", readErr)
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return "", fmt.Errorf("couldn't finish upload: %w", err)
+	}
+
+	return fileName, nil
+}
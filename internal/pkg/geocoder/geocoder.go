@@ -0,0 +1,12 @@
+package geocoder
+
+import "errors"
+
+var ErrAddressNotFound = errors.New("address not found")
+
+// Geocoder resolves delivery addresses sent without coordinates.
+//
+//go:generate mockgen -destination=./geocoder_mock.go -package=geocoder github.com/friends/internal/pkg/geocoder Geocoder
+type Geocoder interface {
+	Geocode(address string) (longitude, latitude float64, err error)
+}
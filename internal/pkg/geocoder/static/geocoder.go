@@ -0,0 +1,64 @@
+package static
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/friends/internal/pkg/geocoder"
+)
+
+// Point is a longitude and latitude pair.
+type Point [2]float64
+
+// Geocoder is a local stand-in for a geocoding service. It knows only
+// the addresses it was created with.
+type Geocoder struct {
+	addresses map[string]Point
+}
+
+func New(addresses map[string]Point) geocoder.Geocoder {
+	normalized := make(map[string]Point, len(addresses))
+	for address, point := range addresses {
+		normalized[normalize(address)] = point
+	}
+
+	return Geocoder{
+		addresses: normalized,
+	}
+}
+
+// Load creates the geocoder from a JSON file of {"address": [longitude, latitude]}.
+// An empty path gives a geocoder without addresses.
+func Load(path string) (geocoder.Geocoder, error) {
+	addresses := make(map[string]Point)
+	if path == "" {
+		return New(addresses), nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read addresses: %w", err)
+	}
+
+	err = json.Unmarshal(data, &addresses)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse addresses: %w", err)
+	}
+
+	return New(addresses), nil
+}
+
+func (g Geocoder) Geocode(address string) (float64, float64, error) {
+	point, ok := g.addresses[normalize(address)]
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q", geocoder.ErrAddressNotFound, address)
+	}
+
+	return point[0], point[1], nil
+}
+
+func normalize(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
@@ -0,0 +1,70 @@
+package static
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/friends/internal/pkg/geocoder"
+)
+
+func TestGeocode(t *testing.T) {
+	g := New(map[string]Point{"Москва, 2-я Бауманская, 5": {37.6867, 55.7659}})
+
+	longitude, latitude, err := g.Geocode("  москва,  2-я бауманская, 5 ")
+
+	if longitude != 37.6867 || latitude != 55.7659 {
+		t.Errorf("expected: %v\n got: %v", Point{37.6867, 55.7659}, Point{longitude, latitude})
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, _, err = g.Geocode("unknown street")
+
+	if !errors.Is(err, geocoder.ErrAddressNotFound) {
+		t.Errorf("expected: %v\n got: %v", geocoder.ErrAddressNotFound, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geocoder")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "addresses.json")
+	err = ioutil.WriteFile(path, []byte(`{"Test street, 1": [37.5, 55.5]}`), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	longitude, latitude, err := g.Geocode("test street, 1")
+	if longitude != 37.5 || latitude != 55.5 || err != nil {
+		t.Errorf("unexpected result: %v, %v, %v", longitude, latitude, err)
+	}
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	g, err = Load("")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, _, err = g.Geocode("test street, 1")
+	if !errors.Is(err, geocoder.ErrAddressNotFound) {
+		t.Errorf("expected: %v\n got: %v", geocoder.ErrAddressNotFound, err)
+	}
+}
@@ -0,0 +1,14 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+type Repository interface {
+	Reserve(userID, key, path, requestHash string, createdAt, expiredBefore time.Time) (bool, error)
+	Get(userID, key string) (models.IdempotentResponse, error)
+	Save(userID, key string, resp models.IdempotentResponse) error
+	Delete(userID, key string) error
+}
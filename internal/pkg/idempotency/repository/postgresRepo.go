@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/friends/internal/pkg/idempotency"
+	"github.com/friends/internal/pkg/models"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) idempotency.Repository {
+	return IdempotencyRepository{
+		db: db,
+	}
+}
+
+// Reserve stores an empty response for the key. It returns false if the key
+// is already used by the user and hasn't expired yet.
+func (i IdempotencyRepository) Reserve(
+	userID, key, path, requestHash string, createdAt, expiredBefore time.Time,
+) (bool, error) {
+	result, err := i.db.Exec(
+		`INSERT INTO idempotency_keys (userID, idem_key, path, request_hash, status_code, body, created_at)
+		VALUES ($1, $2, $3, $4, 0, '', $5)
+		ON CONFLICT (userID, idem_key) DO UPDATE
+		SET path = EXCLUDED.path, request_hash = EXCLUDED.request_hash, status_code = 0, body = '',
+		created_at = EXCLUDED.created_at
+		WHERE idempotency_keys.created_at < $6`,
+		userID, key, path, requestHash, createdAt, expiredBefore,
+	)
+
+	if err != nil {
+		return false, fmt.Errorf("couldn't reserve idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("couldn't check reserved idempotency key: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (i IdempotencyRepository) Get(userID, key string) (models.IdempotentResponse, error) {
+	var resp models.IdempotentResponse
+	err := i.db.QueryRow(
+		"SELECT path, request_hash, status_code, body FROM idempotency_keys WHERE userID = $1 AND idem_key = $2",
+		userID, key,
+	).Scan(&resp.Path, &resp.RequestHash, &resp.StatusCode, &resp.Body)
+
+	if err != nil {
+		return models.IdempotentResponse{}, fmt.Errorf("couldn't get response for idempotency key: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i IdempotencyRepository) Save(userID, key string, resp models.IdempotentResponse) error {
+	_, err := i.db.Exec(
+		"UPDATE idempotency_keys SET status_code = $1, body = $2 WHERE userID = $3 AND idem_key = $4",
+		resp.StatusCode, resp.Body, userID, key,
+	)
+
+	if err != nil {
+		return fmt.Errorf("couldn't save response for idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (i IdempotencyRepository) Delete(userID, key string) error {
+	_, err := i.db.Exec(
+		"DELETE FROM idempotency_keys WHERE userID = $1 AND idem_key = $2",
+		userID, key,
+	)
+
+	if err != nil {
+		return fmt.Errorf("couldn't delete idempotency key: %w", err)
+	}
+
+	return nil
+}
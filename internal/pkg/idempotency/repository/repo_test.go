@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/friends/internal/pkg/models"
+)
+
+var (
+	fatalError = "an error '%s' was not expected when opening a stub database connection"
+
+	userID = "1"
+	key    = "7c1e0a5a"
+	path   = "/api/v1/orders"
+
+	requestHash = "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+
+	dbError = fmt.Errorf("db error")
+)
+
+func TestReserve(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	now := time.Now()
+	expiredBefore := now.Add(-time.Hour)
+
+	// new key
+	mock.
+		ExpectExec("INSERT INTO idempotency_keys").
+		WithArgs(userID, key, path, requestHash, now, expiredBefore).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	reserved, err := repo.Reserve(userID, key, path, requestHash, now, expiredBefore)
+
+	if !reserved {
+		t.Errorf("expected: %v\n got: %v", true, reserved)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// used key
+	mock.
+		ExpectExec("INSERT INTO idempotency_keys").
+		WithArgs(userID, key, path, requestHash, now, expiredBefore).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reserved, err = repo.Reserve(userID, key, path, requestHash, now, expiredBefore)
+
+	if reserved {
+		t.Errorf("expected: %v\n got: %v", false, reserved)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("INSERT INTO idempotency_keys").
+		WithArgs(userID, key, path, requestHash, now, expiredBefore).
+		WillReturnError(dbError)
+
+	_, err = repo.Reserve(userID, key, path, requestHash, now, expiredBefore)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	expected := models.IdempotentResponse{
+		Path:        path,
+		RequestHash: requestHash,
+		StatusCode:  200,
+		Body:        []byte(`{"id":10}`),
+	}
+
+	// good query
+	rows := mock.NewRows([]string{"path", "request_hash", "status_code", "body"}).
+		AddRow(expected.Path, expected.RequestHash, expected.StatusCode, expected.Body)
+
+	mock.
+		ExpectQuery("SELECT path, request_hash, status_code, body FROM idempotency_keys").
+		WithArgs(userID, key).
+		WillReturnRows(rows)
+
+	resp, err := repo.Get(userID, key)
+
+	if !reflect.DeepEqual(expected, resp) {
+		t.Errorf("expected: %v\n got: %v", expected, resp)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT path, request_hash, status_code, body FROM idempotency_keys").
+		WithArgs(userID, key).
+		WillReturnError(dbError)
+
+	_, err = repo.Get(userID, key)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestSave(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	resp := models.IdempotentResponse{
+		Path:       path,
+		StatusCode: 200,
+		Body:       []byte(`{"id":10}`),
+	}
+
+	// good query
+	mock.
+		ExpectExec("UPDATE idempotency_keys").
+		WithArgs(resp.StatusCode, resp.Body, userID, key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Save(userID, key, resp)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("UPDATE idempotency_keys").
+		WithArgs(resp.StatusCode, resp.Body, userID, key).
+		WillReturnError(dbError)
+
+	err = repo.Save(userID, key, resp)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectExec("DELETE FROM idempotency_keys").
+		WithArgs(userID, key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Delete(userID, key)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("DELETE FROM idempotency_keys").
+		WithArgs(userID, key).
+		WillReturnError(dbError)
+
+	err = repo.Delete(userID, key)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/idempotency"
+	"github.com/friends/internal/pkg/models"
+	log "github.com/friends/pkg/logger"
+)
+
+type IdempotencyChecker struct {
+	repository idempotency.Repository
+}
+
+func NewIdempotencyChecker(repository idempotency.Repository) IdempotencyChecker {
+	return IdempotencyChecker{
+		repository: repository,
+	}
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Check replays the stored response when the user repeats a request with
+// the same Idempotency-Key header instead of running the handler again.
+// A key reused with another path or body is rejected. Requests without
+// the header are passed through.
+func (i IdempotencyChecker) Check(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		defer func() {
+			if err != nil {
+				log.ErrorLogWithCtx(r.Context(), err)
+			}
+		}()
+
+		key := r.Header.Get(configs.IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, ok := r.Context().Value(UserID(configs.UserID)).(string)
+		if !ok {
+			err = fmt.Errorf("couldn't get userID from context")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(hash[:])
+
+		now := time.Now()
+		reserved, err := i.repository.Reserve(
+			userID, key, r.URL.Path, requestHash, now, now.Add(-configs.IdempotencyKeyTTL),
+		)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !reserved {
+			var resp models.IdempotentResponse
+			resp, err = i.repository.Get(userID, key)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if resp.Path != r.URL.Path {
+				err = fmt.Errorf("idempotency key is already used for %v", resp.Path)
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+
+			if resp.RequestHash != requestHash {
+				err = fmt.Errorf("idempotency key is already used with another request body")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+
+			// the first request is still being processed
+			if resp.StatusCode == 0 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			w.Header().Set(configs.IdempotentReplayedHeader, "true")
+			w.WriteHeader(resp.StatusCode)
+			_, err = w.Write(resp.Body)
+			return
+		}
+
+		// the key is released if the handler panics, the panic goes on
+		// to the Panic middleware
+		defer func() {
+			if rec := recover(); rec != nil {
+				deleteErr := i.repository.Delete(userID, key)
+				if deleteErr != nil {
+					log.ErrorLogWithCtx(r.Context(), deleteErr)
+				}
+				panic(rec)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// server errors are not stored so that the client can retry
+		if recorder.statusCode >= http.StatusInternalServerError {
+			err = i.repository.Delete(userID, key)
+			return
+		}
+
+		err = i.repository.Save(userID, key, models.IdempotentResponse{
+			Path:       r.URL.Path,
+			StatusCode: recorder.statusCode,
+			Body:       recorder.body.Bytes(),
+		})
+	})
+}
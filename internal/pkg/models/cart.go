@@ -8,6 +8,12 @@ type CartRequest struct {
 	VendorID  string `json:"vendor_id"`
 }
 
+//easyjson:json
+type ProductQuantity struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
 func (c *CartRequest) Sanitize() {
 	p := bluemonday.UGCPolicy()
 	c.ProductID = p.Sanitize(c.ProductID)
@@ -6,22 +6,66 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 )
 
+const (
+	MessageTypeMessage  = "message"
+	MessageTypeRead     = "read"
+	MessageTypeTyping   = "typing"
+	MessageTypePresence = "presence"
+)
+
 type Message struct {
-	Type      string    `json:"type"`
-	OrderID   int       `json:"order_id,omitempty"`
-	UserID    string    `json:"-"`
-	VendorID  int       `json:"vendor_id,omitempty"`
-	IsYourMsg bool      `json:"is_your_msg"`
-	Text      string    `json:"text"`
-	SentAt    time.Time `json:"-"`
-	SentAtStr string    `json:"sent_at"`
+	ID         int       `json:"id,omitempty"`
+	Type       string    `json:"type"`
+	OrderID    int       `json:"order_id,omitempty"`
+	UserID     string    `json:"-"`
+	VendorID   int       `json:"vendor_id,omitempty"`
+	IsYourMsg  bool      `json:"is_your_msg"`
+	Text       string    `json:"text"`
+	Attachment string    `json:"attachment,omitempty"`
+	SentAt     time.Time `json:"-"`
+	SentAtStr  string    `json:"sent_at"`
+	IsRead     bool      `json:"is_read"`
+	ReadAt     time.Time `json:"-"`
+	ReadAtStr  string    `json:"read_at,omitempty"`
+	Presence   *Presence `json:"presence,omitempty"`
+}
+
+type Presence struct {
+	Online      bool      `json:"online"`
+	LastSeen    time.Time `json:"-"`
+	LastSeenStr string    `json:"last_seen,omitempty"`
+}
+
+// ChatHistory is a page of the chat. HasMore tells whether there are more
+// messages in the direction of the page, it's sent in a header to keep
+// the response an array of messages.
+type ChatHistory struct {
+	Messages []Message
+	HasMore  bool
+}
+
+// ChatCursor selects the messages before or after a message. With neither
+// set it selects the latest ones.
+type ChatCursor struct {
+	Before int
+	After  int
+	Limit  int
 }
 
 type Chat struct {
-	OrderID          int    `json:"order_id"`
-	InterlocutorID   string `json:"interlocutor_id"`
-	InterlocutorName string `json:"interlocutor_name"`
-	LastMsg          string `json:"last_message"`
+	OrderID          int       `json:"order_id"`
+	InterlocutorID   string    `json:"interlocutor_id"`
+	InterlocutorName string    `json:"interlocutor_name"`
+	LastMsg          string    `json:"last_message"`
+	LastAttachment   string    `json:"last_attachment,omitempty"`
+	LastMsgAt        time.Time `json:"-"`
+	LastMsgAtStr     string    `json:"last_message_at"`
+	UnreadCount      int       `json:"unread_count"`
+	Presence         Presence  `json:"presence"`
+}
+
+type UnreadResponse struct {
+	Unread int `json:"unread"`
 }
 
 func (m *Message) Sanitaze() {
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+const EventNewOrder = "new_order"
+
+//easyjson:json
+type Event struct {
+	ID           int            `json:"id"`
+	Type         string         `json:"type"`
+	Order        *OrderResponse `json:"order,omitempty"`
+	CreatedAt    time.Time      `json:"-"`
+	CreatedAtStr string         `json:"created_at"`
+}
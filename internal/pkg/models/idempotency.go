@@ -0,0 +1,9 @@
+package models
+
+//easyjson:skip
+type IdempotentResponse struct {
+	Path        string
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+}
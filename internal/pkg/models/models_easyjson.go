@@ -323,6 +323,10 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels2(in *jlexer.Lexer,
 				}
 				in.Delim(']')
 			}
+		case "distance_to":
+			out.DistanceTo = int(in.Int())
+		case "is_open_now":
+			out.IsOpenNow = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -409,6 +413,16 @@ func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels2(out *jwriter.Write
 			out.RawByte(']')
 		}
 	}
+	if in.DistanceTo != 0 {
+		const prefix string = ",\"distance_to\":"
+		out.RawString(prefix)
+		out.Int(int(in.DistanceTo))
+	}
+	{
+		const prefix string = ",\"is_open_now\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsOpenNow))
+	}
 	out.RawByte('}')
 }
 
@@ -522,7 +536,7 @@ func (v *User) UnmarshalJSON(data []byte) error {
 func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels3(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(in *jlexer.Lexer, out *Session) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(in *jlexer.Lexer, out *UnreadResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -541,12 +555,8 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "Name":
-			out.Name = string(in.String())
-		case "UserID":
-			out.UserID = string(in.String())
-		case "ExpireTime":
-			out.ExpireTime = time.Duration(in.Int64())
+		case "unread":
+			out.Unread = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -557,52 +567,42 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels4(out *jwriter.Writer, in Session) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels4(out *jwriter.Writer, in UnreadResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Name\":"
+		const prefix string = ",\"unread\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"UserID\":"
-		out.RawString(prefix)
-		out.String(string(in.UserID))
-	}
-	{
-		const prefix string = ",\"ExpireTime\":"
-		out.RawString(prefix)
-		out.Int64(int64(in.ExpireTime))
+		out.Int(int(in.Unread))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Session) MarshalJSON() ([]byte, error) {
+func (v UnreadResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UnreadResponse) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Session) UnmarshalJSON(data []byte) error {
+func (v *UnreadResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UnreadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels4(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(in *jlexer.Lexer, out *Review) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(in *jlexer.Lexer, out *Session) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -621,16 +621,12 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "username":
-			out.Username = string(in.String())
-		case "order_id":
-			out.OrderID = int(in.Int())
-		case "rating":
-			out.Rating = int(in.Int())
-		case "text":
-			out.Text = string(in.String())
-		case "created_at":
-			out.CreatedAtStr = string(in.String())
+		case "Name":
+			out.Name = string(in.String())
+		case "UserID":
+			out.UserID = string(in.String())
+		case "ExpireTime":
+			out.ExpireTime = time.Duration(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -641,67 +637,52 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels5(out *jwriter.Writer, in Review) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels5(out *jwriter.Writer, in Session) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"username\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Username))
-	}
-	{
-		const prefix string = ",\"order_id\":"
-		out.RawString(prefix)
-		out.Int(int(in.OrderID))
-	}
-	{
-		const prefix string = ",\"rating\":"
-		out.RawString(prefix)
-		out.Int(int(in.Rating))
+		const prefix string = ",\"Name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"text\":"
+		const prefix string = ",\"UserID\":"
 		out.RawString(prefix)
-		out.String(string(in.Text))
+		out.String(string(in.UserID))
 	}
 	{
-		const prefix string = ",\"created_at\":"
+		const prefix string = ",\"ExpireTime\":"
 		out.RawString(prefix)
-		out.String(string(in.CreatedAtStr))
+		out.Int64(int64(in.ExpireTime))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Review) MarshalJSON() ([]byte, error) {
+func (v Session) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Review) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Review) UnmarshalJSON(data []byte) error {
+func (v *Session) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Review) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels5(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(in *jlexer.Lexer, out *Profile) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(in *jlexer.Lexer, out *Schedule) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -720,39 +701,58 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "userId":
-			out.UserID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "phone":
-			out.Phone = string(in.String())
-		case "addresses":
+		case "time_zone":
+			out.TimeZone = string(in.String())
+		case "hours":
 			if in.IsNull() {
 				in.Skip()
-				out.Addresses = nil
+				out.Hours = nil
 			} else {
 				in.Delim('[')
-				if out.Addresses == nil {
+				if out.Hours == nil {
 					if !in.IsDelim(']') {
-						out.Addresses = make([]string, 0, 4)
+						out.Hours = make([]OpeningHours, 0, 1)
 					} else {
-						out.Addresses = []string{}
+						out.Hours = []OpeningHours{}
 					}
 				} else {
-					out.Addresses = (out.Addresses)[:0]
+					out.Hours = (out.Hours)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v13 string
-					v13 = string(in.String())
-					out.Addresses = append(out.Addresses, v13)
+					var v13 OpeningHours
+					(v13).UnmarshalEasyJSON(in)
+					out.Hours = append(out.Hours, v13)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "points":
-			out.Points = int(in.Int())
-		case "avatar":
-			out.Avatar = string(in.String())
+		case "holidays":
+			if in.IsNull() {
+				in.Skip()
+				out.Holidays = nil
+			} else {
+				in.Delim('[')
+				if out.Holidays == nil {
+					if !in.IsDelim(']') {
+						out.Holidays = make([]string, 0, 4)
+					} else {
+						out.Holidays = []string{}
+					}
+				} else {
+					out.Holidays = (out.Holidays)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 string
+					v14 = string(in.String())
+					out.Holidays = append(out.Holidays, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "paused":
+			out.Paused = bool(in.Bool())
+		case "is_open_now":
+			out.IsOpenNow = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -763,78 +763,84 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels6(out *jwriter.Writer, in Profile) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels6(out *jwriter.Writer, in Schedule) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"userId\":"
+		const prefix string = ",\"time_zone\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.UserID))
+		out.String(string(in.TimeZone))
 	}
 	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"phone\":"
+		const prefix string = ",\"hours\":"
 		out.RawString(prefix)
-		out.String(string(in.Phone))
+		if in.Hours == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v15, v16 := range in.Hours {
+				if v15 > 0 {
+					out.RawByte(',')
+				}
+				(v16).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"addresses\":"
+		const prefix string = ",\"holidays\":"
 		out.RawString(prefix)
-		if in.Addresses == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Holidays == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v14, v15 := range in.Addresses {
-				if v14 > 0 {
+			for v17, v18 := range in.Holidays {
+				if v17 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v15))
+				out.String(string(v18))
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"points\":"
+		const prefix string = ",\"paused\":"
 		out.RawString(prefix)
-		out.Int(int(in.Points))
+		out.Bool(bool(in.Paused))
 	}
 	{
-		const prefix string = ",\"avatar\":"
+		const prefix string = ",\"is_open_now\":"
 		out.RawString(prefix)
-		out.String(string(in.Avatar))
+		out.Bool(bool(in.IsOpenNow))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Profile) MarshalJSON() ([]byte, error) {
+func (v Schedule) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Profile) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Schedule) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Profile) UnmarshalJSON(data []byte) error {
+func (v *Schedule) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Profile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Schedule) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels6(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(in *jlexer.Lexer, out *Product) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(in *jlexer.Lexer, out *Review) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -853,18 +859,16 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = int(in.Int())
-		case "picture":
-			out.Picture = string(in.String())
-		case "food_name":
-			out.Name = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "food_price":
-			out.Price = int(in.Int())
-		case "vendor_id":
-			out.VendorID = int(in.Int())
+		case "username":
+			out.Username = string(in.String())
+		case "order_id":
+			out.OrderID = int(in.Int())
+		case "rating":
+			out.Rating = int(in.Int())
+		case "text":
+			out.Text = string(in.String())
+		case "created_at":
+			out.CreatedAtStr = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -875,67 +879,67 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels7(out *jwriter.Writer, in Product) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels7(out *jwriter.Writer, in Review) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
-	}
-	{
-		const prefix string = ",\"picture\":"
-		out.RawString(prefix)
-		out.String(string(in.Picture))
+		const prefix string = ",\"username\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Username))
 	}
 	{
-		const prefix string = ",\"food_name\":"
+		const prefix string = ",\"order_id\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		out.Int(int(in.OrderID))
 	}
 	{
-		const prefix string = ",\"description\":"
+		const prefix string = ",\"rating\":"
 		out.RawString(prefix)
-		out.String(string(in.Description))
+		out.Int(int(in.Rating))
 	}
 	{
-		const prefix string = ",\"food_price\":"
+		const prefix string = ",\"text\":"
 		out.RawString(prefix)
-		out.Int(int(in.Price))
+		out.String(string(in.Text))
 	}
 	{
-		const prefix string = ",\"vendor_id\":"
+		const prefix string = ",\"created_at\":"
 		out.RawString(prefix)
-		out.Int(int(in.VendorID))
+		out.String(string(in.CreatedAtStr))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Product) MarshalJSON() ([]byte, error) {
+func (v Review) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Product) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Review) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Product) UnmarshalJSON(data []byte) error {
+func (v *Review) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Product) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Review) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels7(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(in *jlexer.Lexer, out *OrderStatusRequest) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(in *jlexer.Lexer, out *RefundRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -954,8 +958,31 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "status":
-			out.Status = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]RefundItem, 0, 4)
+					} else {
+						out.Items = []RefundItem{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 RefundItem
+					(v19).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -966,42 +993,58 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels8(out *jwriter.Writer, in OrderStatusRequest) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels8(out *jwriter.Writer, in RefundRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"reason\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Status))
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Items {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OrderStatusRequest) MarshalJSON() ([]byte, error) {
+func (v RefundRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OrderStatusRequest) MarshalEasyJSON(w *jwriter.Writer) {
+func (v RefundRequest) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OrderStatusRequest) UnmarshalJSON(data []byte) error {
+func (v *RefundRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OrderStatusRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *RefundRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels8(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(in *jlexer.Lexer, out *OrderStatusMessage) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(in *jlexer.Lexer, out *RefundItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1020,16 +1063,10 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "order_id":
-			out.OrderID = string(in.String())
-		case "vendor_name":
-			out.VendorName = string(in.String())
-		case "vendor_picture":
-			out.VendorPicture = string(in.String())
-		case "order_status":
-			out.Status = string(in.String())
+		case "item_id":
+			out.ItemID = int(in.Int())
+		case "quantity":
+			out.Quantity = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1040,62 +1077,47 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels9(out *jwriter.Writer, in OrderStatusMessage) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels9(out *jwriter.Writer, in RefundItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"item_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	{
-		const prefix string = ",\"order_id\":"
-		out.RawString(prefix)
-		out.String(string(in.OrderID))
+		out.Int(int(in.ItemID))
 	}
 	{
-		const prefix string = ",\"vendor_name\":"
+		const prefix string = ",\"quantity\":"
 		out.RawString(prefix)
-		out.String(string(in.VendorName))
-	}
-	{
-		const prefix string = ",\"vendor_picture\":"
-		out.RawString(prefix)
-		out.String(string(in.VendorPicture))
-	}
-	{
-		const prefix string = ",\"order_status\":"
-		out.RawString(prefix)
-		out.String(string(in.Status))
+		out.Int(int(in.Quantity))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OrderStatusMessage) MarshalJSON() ([]byte, error) {
+func (v RefundItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OrderStatusMessage) MarshalEasyJSON(w *jwriter.Writer) {
+func (v RefundItem) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OrderStatusMessage) UnmarshalJSON(data []byte) error {
+func (v *RefundItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OrderStatusMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *RefundItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels9(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(in *jlexer.Lexer, out *OrderResponse) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(in *jlexer.Lexer, out *Refund) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1116,43 +1138,152 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(in *jlexer.Lexer,
 		switch key {
 		case "id":
 			out.ID = int(in.Int())
-		case "user_id":
-			out.UserID = int(in.Int())
-		case "vendor_name":
-			out.VendorName = string(in.String())
-		case "products":
+		case "order_id":
+			out.OrderID = int(in.Int())
+		case "item_id":
+			out.ItemID = int(in.Int())
+		case "quantity":
+			out.Quantity = int(in.Int())
+		case "amount":
+			out.Amount = int(in.Int())
+		case "reason":
+			out.Reason = string(in.String())
+		case "initiator_id":
+			out.InitiatorID = string(in.String())
+		case "created_at":
+			out.CreatedAtStr = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(out *jwriter.Writer, in Refund) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.OrderID))
+	}
+	if in.ItemID != 0 {
+		const prefix string = ",\"item_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.ItemID))
+	}
+	if in.Quantity != 0 {
+		const prefix string = ",\"quantity\":"
+		out.RawString(prefix)
+		out.Int(int(in.Quantity))
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.Amount))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"initiator_id\":"
+		out.RawString(prefix)
+		out.String(string(in.InitiatorID))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAtStr))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Refund) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Refund) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Refund) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Refund) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(in *jlexer.Lexer, out *Profile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userId":
+			out.UserID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "phone":
+			out.Phone = string(in.String())
+		case "addresses":
 			if in.IsNull() {
 				in.Skip()
-				out.Products = nil
+				out.Addresses = nil
 			} else {
 				in.Delim('[')
-				if out.Products == nil {
+				if out.Addresses == nil {
 					if !in.IsDelim(']') {
-						out.Products = make([]OrderProduct, 0, 1)
+						out.Addresses = make([]string, 0, 4)
 					} else {
-						out.Products = []OrderProduct{}
+						out.Addresses = []string{}
 					}
 				} else {
-					out.Products = (out.Products)[:0]
+					out.Addresses = (out.Addresses)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v16 OrderProduct
-					(v16).UnmarshalEasyJSON(in)
-					out.Products = append(out.Products, v16)
+					var v22 string
+					v22 = string(in.String())
+					out.Addresses = append(out.Addresses, v22)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "created_at":
-			out.CreatedAtStr = string(in.String())
-		case "address":
-			out.Address = string(in.String())
-		case "status":
-			out.Status = string(in.String())
-		case "price":
-			out.Price = int(in.Int())
-		case "reviewed":
-			out.Reviewed = bool(in.Bool())
+		case "points":
+			out.Points = int(in.Int())
+		case "avatar":
+			out.Avatar = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1163,93 +1294,151 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(out *jwriter.Writer, in OrderResponse) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(out *jwriter.Writer, in Profile) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"userId\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
+		out.String(string(in.UserID))
 	}
 	{
-		const prefix string = ",\"user_id\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.Int(int(in.UserID))
+		out.String(string(in.Name))
 	}
-	if in.VendorName != "" {
-		const prefix string = ",\"vendor_name\":"
+	{
+		const prefix string = ",\"phone\":"
 		out.RawString(prefix)
-		out.String(string(in.VendorName))
+		out.String(string(in.Phone))
 	}
 	{
-		const prefix string = ",\"products\":"
+		const prefix string = ",\"addresses\":"
 		out.RawString(prefix)
-		if in.Products == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Addresses == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v17, v18 := range in.Products {
-				if v17 > 0 {
+			for v23, v24 := range in.Addresses {
+				if v23 > 0 {
 					out.RawByte(',')
 				}
-				(v18).MarshalEasyJSON(out)
+				out.String(string(v24))
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"created_at\":"
+		const prefix string = ",\"points\":"
 		out.RawString(prefix)
-		out.String(string(in.CreatedAtStr))
+		out.Int(int(in.Points))
 	}
 	{
-		const prefix string = ",\"address\":"
+		const prefix string = ",\"avatar\":"
 		out.RawString(prefix)
-		out.String(string(in.Address))
+		out.String(string(in.Avatar))
 	}
-	{
-		const prefix string = ",\"status\":"
-		out.RawString(prefix)
-		out.String(string(in.Status))
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Profile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Profile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Profile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Profile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(in *jlexer.Lexer, out *ProductQuantity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "product_id":
+			out.ProductID = int(in.Int())
+		case "quantity":
+			out.Quantity = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(out *jwriter.Writer, in ProductQuantity) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"price\":"
-		out.RawString(prefix)
-		out.Int(int(in.Price))
+		const prefix string = ",\"product_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ProductID))
 	}
 	{
-		const prefix string = ",\"reviewed\":"
+		const prefix string = ",\"quantity\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.Reviewed))
+		out.Int(int(in.Quantity))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OrderResponse) MarshalJSON() ([]byte, error) {
+func (v ProductQuantity) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OrderResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels10(w, v)
+func (v ProductQuantity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OrderResponse) UnmarshalJSON(data []byte) error {
+func (v *ProductQuantity) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OrderResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels10(l, v)
+func (v *ProductQuantity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(in *jlexer.Lexer, out *OrderRequest) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(in *jlexer.Lexer, out *Product) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1268,31 +1457,1741 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "products":
-			if in.IsNull() {
-				in.Skip()
-				out.ProductIDs = nil
-			} else {
-				in.Delim('[')
-				if out.ProductIDs == nil {
-					if !in.IsDelim(']') {
-						out.ProductIDs = make([]int, 0, 8)
-					} else {
-						out.ProductIDs = []int{}
-					}
-				} else {
-					out.ProductIDs = (out.ProductIDs)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v19 int
-					v19 = int(in.Int())
-					out.ProductIDs = append(out.ProductIDs, v19)
-					in.WantComma()
+		case "id":
+			out.ID = int(in.Int())
+		case "picture":
+			out.Picture = string(in.String())
+		case "food_name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "food_price":
+			out.Price = int(in.Int())
+		case "vendor_id":
+			out.VendorID = int(in.Int())
+		case "quantity":
+			out.Quantity = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(out *jwriter.Writer, in Product) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"picture\":"
+		out.RawString(prefix)
+		out.String(string(in.Picture))
+	}
+	{
+		const prefix string = ",\"food_name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"food_price\":"
+		out.RawString(prefix)
+		out.Int(int(in.Price))
+	}
+	{
+		const prefix string = ",\"vendor_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.VendorID))
+	}
+	if in.Quantity != 0 {
+		const prefix string = ",\"quantity\":"
+		out.RawString(prefix)
+		out.Int(int(in.Quantity))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Product) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Product) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Product) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Product) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(in *jlexer.Lexer, out *Presence) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "online":
+			out.Online = bool(in.Bool())
+		case "last_seen":
+			out.LastSeenStr = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(out *jwriter.Writer, in Presence) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"online\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Online))
+	}
+	if in.LastSeenStr != "" {
+		const prefix string = ",\"last_seen\":"
+		out.RawString(prefix)
+		out.String(string(in.LastSeenStr))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Presence) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Presence) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Presence) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Presence) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(in *jlexer.Lexer, out *PaymentIntent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "order_id":
+			out.OrderID = int(in.Int())
+		case "amount":
+			out.Amount = int(in.Int())
+		case "refunded":
+			out.Refunded = int(in.Int())
+		case "status":
+			out.Status = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(out *jwriter.Writer, in PaymentIntent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.OrderID))
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.Amount))
+	}
+	{
+		const prefix string = ",\"refunded\":"
+		out.RawString(prefix)
+		out.Int(int(in.Refunded))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PaymentIntent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PaymentIntent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PaymentIntent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PaymentIntent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(in *jlexer.Lexer, out *PaymentEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "intent_id":
+			out.IntentID = string(in.String())
+		case "order_id":
+			out.OrderID = int(in.Int())
+		case "amount":
+			out.Amount = int(in.Int())
+		case "status":
+			out.Status = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(out *jwriter.Writer, in PaymentEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"intent_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.IntentID))
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.OrderID))
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.Amount))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PaymentEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PaymentEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PaymentEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PaymentEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(in *jlexer.Lexer, out *PauseRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "paused":
+			out.Paused = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(out *jwriter.Writer, in PauseRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"paused\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Paused))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PauseRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PauseRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PauseRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PauseRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(in *jlexer.Lexer, out *OrderStatusRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(out *jwriter.Writer, in OrderStatusRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(in *jlexer.Lexer, out *OrderStatusMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "order_id":
+			out.OrderID = string(in.String())
+		case "vendor_name":
+			out.VendorName = string(in.String())
+		case "vendor_picture":
+			out.VendorPicture = string(in.String())
+		case "order_status":
+			out.Status = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(out *jwriter.Writer, in OrderStatusMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.String(string(in.OrderID))
+	}
+	{
+		const prefix string = ",\"vendor_name\":"
+		out.RawString(prefix)
+		out.String(string(in.VendorName))
+	}
+	{
+		const prefix string = ",\"vendor_picture\":"
+		out.RawString(prefix)
+		out.String(string(in.VendorPicture))
+	}
+	{
+		const prefix string = ",\"order_status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels20(in *jlexer.Lexer, out *OrderStatusChange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		case "changed_at":
+			out.ChangedAtStr = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels20(out *jwriter.Writer, in OrderStatusChange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	{
+		const prefix string = ",\"changed_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ChangedAtStr))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusChange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusChange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusChange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusChange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels20(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels21(in *jlexer.Lexer, out *OrderResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "user_id":
+			out.UserID = int(in.Int())
+		case "vendor_name":
+			out.VendorName = string(in.String())
+		case "products":
+			if in.IsNull() {
+				in.Skip()
+				out.Products = nil
+			} else {
+				in.Delim('[')
+				if out.Products == nil {
+					if !in.IsDelim(']') {
+						out.Products = make([]OrderProduct, 0, 1)
+					} else {
+						out.Products = []OrderProduct{}
+					}
+				} else {
+					out.Products = (out.Products)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 OrderProduct
+					(v25).UnmarshalEasyJSON(in)
+					out.Products = append(out.Products, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_at":
+			out.CreatedAtStr = string(in.String())
+		case "deliver_at":
+			out.DeliverAtStr = string(in.String())
+		case "address":
+			out.Address = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "price":
+			out.Price = int(in.Int())
+		case "delivery_fee":
+			out.DeliveryFee = int(in.Int())
+		case "reviewed":
+			out.Reviewed = bool(in.Bool())
+		case "payment_status":
+			out.PaymentStatus = string(in.String())
+		case "refunded_amount":
+			out.RefundedAmount = int(in.Int())
+		case "refunds":
+			if in.IsNull() {
+				in.Skip()
+				out.Refunds = nil
+			} else {
+				in.Delim('[')
+				if out.Refunds == nil {
+					if !in.IsDelim(']') {
+						out.Refunds = make([]Refund, 0, 0)
+					} else {
+						out.Refunds = []Refund{}
+					}
+				} else {
+					out.Refunds = (out.Refunds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v26 Refund
+					(v26).UnmarshalEasyJSON(in)
+					out.Refunds = append(out.Refunds, v26)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "cancel_reason":
+			out.CancelReason = string(in.String())
+		case "history":
+			if in.IsNull() {
+				in.Skip()
+				out.History = nil
+			} else {
+				in.Delim('[')
+				if out.History == nil {
+					if !in.IsDelim(']') {
+						out.History = make([]OrderStatusChange, 0, 0)
+					} else {
+						out.History = []OrderStatusChange{}
+					}
+				} else {
+					out.History = (out.History)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v27 OrderStatusChange
+					(v27).UnmarshalEasyJSON(in)
+					out.History = append(out.History, v27)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels21(out *jwriter.Writer, in OrderResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.UserID))
+	}
+	if in.VendorName != "" {
+		const prefix string = ",\"vendor_name\":"
+		out.RawString(prefix)
+		out.String(string(in.VendorName))
+	}
+	{
+		const prefix string = ",\"products\":"
+		out.RawString(prefix)
+		if in.Products == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v28, v29 := range in.Products {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				(v29).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAtStr))
+	}
+	if in.DeliverAtStr != "" {
+		const prefix string = ",\"deliver_at\":"
+		out.RawString(prefix)
+		out.String(string(in.DeliverAtStr))
+	}
+	{
+		const prefix string = ",\"address\":"
+		out.RawString(prefix)
+		out.String(string(in.Address))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		out.Int(int(in.Price))
+	}
+	{
+		const prefix string = ",\"delivery_fee\":"
+		out.RawString(prefix)
+		out.Int(int(in.DeliveryFee))
+	}
+	{
+		const prefix string = ",\"reviewed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Reviewed))
+	}
+	{
+		const prefix string = ",\"payment_status\":"
+		out.RawString(prefix)
+		out.String(string(in.PaymentStatus))
+	}
+	{
+		const prefix string = ",\"refunded_amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.RefundedAmount))
+	}
+	if len(in.Refunds) != 0 {
+		const prefix string = ",\"refunds\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v30, v31 := range in.Refunds {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				(v31).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.CancelReason != "" {
+		const prefix string = ",\"cancel_reason\":"
+		out.RawString(prefix)
+		out.String(string(in.CancelReason))
+	}
+	if len(in.History) != 0 {
+		const prefix string = ",\"history\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v32, v33 := range in.History {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				(v33).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels21(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels22(in *jlexer.Lexer, out *OrderRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "products":
+			if in.IsNull() {
+				in.Skip()
+				out.ProductIDs = nil
+			} else {
+				in.Delim('[')
+				if out.ProductIDs == nil {
+					if !in.IsDelim(']') {
+						out.ProductIDs = make([]int, 0, 8)
+					} else {
+						out.ProductIDs = []int{}
+					}
+				} else {
+					out.ProductIDs = (out.ProductIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 int
+					v34 = int(in.Int())
+					out.ProductIDs = append(out.ProductIDs, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]ProductQuantity, 0, 4)
+					} else {
+						out.Items = []ProductQuantity{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v35 ProductQuantity
+					(v35).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v35)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "address":
+			out.Address = string(in.String())
+		case "longitude":
+			out.Longitude = float64(in.Float64())
+		case "latitude":
+			out.Latitude = float64(in.Float64())
+		case "deliver_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DeliverAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels22(out *jwriter.Writer, in OrderRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"products\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.ProductIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v36, v37 := range in.ProductIDs {
+				if v36 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v37))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v38, v39 := range in.Items {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				(v39).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"address\":"
+		out.RawString(prefix)
+		out.String(string(in.Address))
+	}
+	{
+		const prefix string = ",\"longitude\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Longitude))
+	}
+	{
+		const prefix string = ",\"latitude\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Latitude))
+	}
+	{
+		const prefix string = ",\"deliver_at\":"
+		out.RawString(prefix)
+		out.Raw((in.DeliverAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels22(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels23(in *jlexer.Lexer, out *OrderProduct) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "picture":
+			out.Picture = string(in.String())
+		case "food_name":
+			out.Name = string(in.String())
+		case "food_price":
+			out.Price = int(in.Int())
+		case "quantity":
+			out.Quantity = int(in.Int())
+		case "refunded_quantity":
+			out.Refunded = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels23(out *jwriter.Writer, in OrderProduct) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"picture\":"
+		out.RawString(prefix)
+		out.String(string(in.Picture))
+	}
+	{
+		const prefix string = ",\"food_name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"food_price\":"
+		out.RawString(prefix)
+		out.Int(int(in.Price))
+	}
+	{
+		const prefix string = ",\"quantity\":"
+		out.RawString(prefix)
+		out.Int(int(in.Quantity))
+	}
+	{
+		const prefix string = ",\"refunded_quantity\":"
+		out.RawString(prefix)
+		out.Int(int(in.Refunded))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderProduct) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderProduct) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderProduct) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderProduct) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels23(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels24(in *jlexer.Lexer, out *OrderCancelRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels24(out *jwriter.Writer, in OrderCancelRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderCancelRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderCancelRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderCancelRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderCancelRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels24(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels25(in *jlexer.Lexer, out *OpeningHours) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "weekday":
+			out.Weekday = int(in.Int())
+		case "opens":
+			out.Opens = string(in.String())
+		case "closes":
+			out.Closes = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels25(out *jwriter.Writer, in OpeningHours) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"weekday\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Weekday))
+	}
+	{
+		const prefix string = ",\"opens\":"
+		out.RawString(prefix)
+		out.String(string(in.Opens))
+	}
+	{
+		const prefix string = ",\"closes\":"
+		out.RawString(prefix)
+		out.String(string(in.Closes))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OpeningHours) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OpeningHours) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OpeningHours) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OpeningHours) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels25(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels26(in *jlexer.Lexer, out *Message) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "order_id":
+			out.OrderID = int(in.Int())
+		case "vendor_id":
+			out.VendorID = int(in.Int())
+		case "is_your_msg":
+			out.IsYourMsg = bool(in.Bool())
+		case "text":
+			out.Text = string(in.String())
+		case "attachment":
+			out.Attachment = string(in.String())
+		case "sent_at":
+			out.SentAtStr = string(in.String())
+		case "is_read":
+			out.IsRead = bool(in.Bool())
+		case "read_at":
+			out.ReadAtStr = string(in.String())
+		case "presence":
+			if in.IsNull() {
+				in.Skip()
+				out.Presence = nil
+			} else {
+				if out.Presence == nil {
+					out.Presence = new(Presence)
+				}
+				(*out.Presence).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels26(out *jwriter.Writer, in Message) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.OrderID != 0 {
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.OrderID))
+	}
+	if in.VendorID != 0 {
+		const prefix string = ",\"vendor_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.VendorID))
+	}
+	{
+		const prefix string = ",\"is_your_msg\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsYourMsg))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	if in.Attachment != "" {
+		const prefix string = ",\"attachment\":"
+		out.RawString(prefix)
+		out.String(string(in.Attachment))
+	}
+	{
+		const prefix string = ",\"sent_at\":"
+		out.RawString(prefix)
+		out.String(string(in.SentAtStr))
+	}
+	{
+		const prefix string = ",\"is_read\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsRead))
+	}
+	if in.ReadAtStr != "" {
+		const prefix string = ",\"read_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ReadAtStr))
+	}
+	if in.Presence != nil {
+		const prefix string = ",\"presence\":"
+		out.RawString(prefix)
+		(*in.Presence).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Message) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Message) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels26(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels27(in *jlexer.Lexer, out *ImgResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "avatar":
+			out.Avatar = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels27(out *jwriter.Writer, in ImgResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"avatar\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Avatar))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImgResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImgResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImgResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImgResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels27(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels28(in *jlexer.Lexer, out *IDResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels28(out *jwriter.Writer, in IDResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IDResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IDResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IDResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IDResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels28(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels29(in *jlexer.Lexer, out *IDRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels29(out *jwriter.Writer, in IDRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IDRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IDRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IDRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels29(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels30(in *jlexer.Lexer, out *Event) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "order":
+			if in.IsNull() {
+				in.Skip()
+				out.Order = nil
+			} else {
+				if out.Order == nil {
+					out.Order = new(OrderResponse)
 				}
-				in.Delim(']')
+				(*out.Order).UnmarshalEasyJSON(in)
 			}
-		case "address":
-			out.Address = string(in.String())
+		case "created_at":
+			out.CreatedAtStr = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1303,63 +3202,57 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(out *jwriter.Writer, in OrderRequest) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels30(out *jwriter.Writer, in Event) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"products\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		if in.ProductIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v20, v21 := range in.ProductIDs {
-				if v20 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v21))
-			}
-			out.RawByte(']')
-		}
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
 	}
 	{
-		const prefix string = ",\"address\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		out.String(string(in.Address))
+		out.String(string(in.Type))
+	}
+	if in.Order != nil {
+		const prefix string = ",\"order\":"
+		out.RawString(prefix)
+		(*in.Order).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAtStr))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OrderRequest) MarshalJSON() ([]byte, error) {
+func (v Event) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels30(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OrderRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels11(w, v)
+func (v Event) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels30(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OrderRequest) UnmarshalJSON(data []byte) error {
+func (v *Event) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels30(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OrderRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels11(l, v)
+func (v *Event) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels30(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(in *jlexer.Lexer, out *OrderProduct) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels31(in *jlexer.Lexer, out *DeliveryZone) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1378,12 +3271,88 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "picture":
-			out.Picture = string(in.String())
-		case "food_name":
-			out.Name = string(in.String())
-		case "food_price":
-			out.Price = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "coordinates":
+			if in.IsNull() {
+				in.Skip()
+				out.Coordinates = nil
+			} else {
+				in.Delim('[')
+				if out.Coordinates == nil {
+					if !in.IsDelim(']') {
+						out.Coordinates = make([][][][2]float64, 0, 2)
+					} else {
+						out.Coordinates = [][][][2]float64{}
+					}
+				} else {
+					out.Coordinates = (out.Coordinates)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 [][][2]float64
+					if in.IsNull() {
+						in.Skip()
+						v40 = nil
+					} else {
+						in.Delim('[')
+						if v40 == nil {
+							if !in.IsDelim(']') {
+								v40 = make([][][2]float64, 0, 2)
+							} else {
+								v40 = [][][2]float64{}
+							}
+						} else {
+							v40 = (v40)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v41 [][2]float64
+							if in.IsNull() {
+								in.Skip()
+								v41 = nil
+							} else {
+								in.Delim('[')
+								if v41 == nil {
+									if !in.IsDelim(']') {
+										v41 = make([][2]float64, 0, 4)
+									} else {
+										v41 = [][2]float64{}
+									}
+								} else {
+									v41 = (v41)[:0]
+								}
+								for !in.IsDelim(']') {
+									var v42 [2]float64
+									if in.IsNull() {
+										in.Skip()
+									} else {
+										in.Delim('[')
+										v43 := 0
+										for !in.IsDelim(']') {
+											if v43 < 2 {
+												(v42)[v43] = float64(in.Float64())
+												v43++
+											} else {
+												in.SkipRecursive()
+											}
+											in.WantComma()
+										}
+										in.Delim(']')
+									}
+									v41 = append(v41, v42)
+									in.WantComma()
+								}
+								in.Delim(']')
+							}
+							v40 = append(v40, v41)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					out.Coordinates = append(out.Coordinates, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1394,52 +3363,87 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(out *jwriter.Writer, in OrderProduct) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels31(out *jwriter.Writer, in DeliveryZone) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"picture\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Picture))
-	}
-	{
-		const prefix string = ",\"food_name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
+		out.String(string(in.Type))
 	}
 	{
-		const prefix string = ",\"food_price\":"
+		const prefix string = ",\"coordinates\":"
 		out.RawString(prefix)
-		out.Int(int(in.Price))
+		if in.Coordinates == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v44, v45 := range in.Coordinates {
+				if v44 > 0 {
+					out.RawByte(',')
+				}
+				if v45 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v46, v47 := range v45 {
+						if v46 > 0 {
+							out.RawByte(',')
+						}
+						if v47 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+							out.RawString("null")
+						} else {
+							out.RawByte('[')
+							for v48, v49 := range v47 {
+								if v48 > 0 {
+									out.RawByte(',')
+								}
+								out.RawByte('[')
+								for v50 := range v49 {
+									if v50 > 0 {
+										out.RawByte(',')
+									}
+									out.Float64(float64((v49)[v50]))
+								}
+								out.RawByte(']')
+							}
+							out.RawByte(']')
+						}
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OrderProduct) MarshalJSON() ([]byte, error) {
+func (v DeliveryZone) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels31(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OrderProduct) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels12(w, v)
+func (v DeliveryZone) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels31(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OrderProduct) UnmarshalJSON(data []byte) error {
+func (v *DeliveryZone) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels31(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OrderProduct) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels12(l, v)
+func (v *DeliveryZone) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels31(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(in *jlexer.Lexer, out *Message) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels32(in *jlexer.Lexer, out *DeliverySettings) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1458,18 +3462,31 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "order_id":
-			out.OrderID = int(in.Int())
-		case "vendor_id":
-			out.VendorID = int(in.Int())
-		case "is_your_msg":
-			out.IsYourMsg = bool(in.Bool())
-		case "text":
-			out.Text = string(in.String())
-		case "sent_at":
-			out.SentAtStr = string(in.String())
+		case "min_order_amount":
+			out.MinOrderAmount = int(in.Int())
+		case "fee_tiers":
+			if in.IsNull() {
+				in.Skip()
+				out.FeeTiers = nil
+			} else {
+				in.Delim('[')
+				if out.FeeTiers == nil {
+					if !in.IsDelim(']') {
+						out.FeeTiers = make([]DeliveryFeeTier, 0, 4)
+					} else {
+						out.FeeTiers = []DeliveryFeeTier{}
+					}
+				} else {
+					out.FeeTiers = (out.FeeTiers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 DeliveryFeeTier
+					(v51).UnmarshalEasyJSON(in)
+					out.FeeTiers = append(out.FeeTiers, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1480,67 +3497,58 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(out *jwriter.Writer, in Message) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels32(out *jwriter.Writer, in DeliverySettings) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"min_order_amount\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	if in.OrderID != 0 {
-		const prefix string = ",\"order_id\":"
-		out.RawString(prefix)
-		out.Int(int(in.OrderID))
-	}
-	if in.VendorID != 0 {
-		const prefix string = ",\"vendor_id\":"
-		out.RawString(prefix)
-		out.Int(int(in.VendorID))
-	}
-	{
-		const prefix string = ",\"is_your_msg\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsYourMsg))
-	}
-	{
-		const prefix string = ",\"text\":"
-		out.RawString(prefix)
-		out.String(string(in.Text))
+		out.Int(int(in.MinOrderAmount))
 	}
 	{
-		const prefix string = ",\"sent_at\":"
+		const prefix string = ",\"fee_tiers\":"
 		out.RawString(prefix)
-		out.String(string(in.SentAtStr))
+		if in.FeeTiers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v52, v53 := range in.FeeTiers {
+				if v52 > 0 {
+					out.RawByte(',')
+				}
+				(v53).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Message) MarshalJSON() ([]byte, error) {
+func (v DeliverySettings) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels32(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels13(w, v)
+func (v DeliverySettings) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels32(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Message) UnmarshalJSON(data []byte) error {
+func (v *DeliverySettings) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels32(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels13(l, v)
+func (v *DeliverySettings) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels32(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(in *jlexer.Lexer, out *ImgResponse) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels33(in *jlexer.Lexer, out *DeliveryFeeTier) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1559,8 +3567,10 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "avatar":
-			out.Avatar = string(in.String())
+		case "max_distance":
+			out.MaxDistance = int(in.Int())
+		case "fee":
+			out.Fee = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1571,42 +3581,47 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(out *jwriter.Writer, in ImgResponse) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels33(out *jwriter.Writer, in DeliveryFeeTier) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"avatar\":"
+		const prefix string = ",\"max_distance\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Avatar))
+		out.Int(int(in.MaxDistance))
+	}
+	{
+		const prefix string = ",\"fee\":"
+		out.RawString(prefix)
+		out.Int(int(in.Fee))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ImgResponse) MarshalJSON() ([]byte, error) {
+func (v DeliveryFeeTier) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels33(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ImgResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels14(w, v)
+func (v DeliveryFeeTier) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels33(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ImgResponse) UnmarshalJSON(data []byte) error {
+func (v *DeliveryFeeTier) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels33(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ImgResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels14(l, v)
+func (v *DeliveryFeeTier) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels33(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(in *jlexer.Lexer, out *IDResponse) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels34(in *jlexer.Lexer, out *ChatHistory) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1625,8 +3640,31 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = int(in.Int())
+		case "Messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]Message, 0, 0)
+					} else {
+						out.Messages = []Message{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v54 Message
+					(v54).UnmarshalEasyJSON(in)
+					out.Messages = append(out.Messages, v54)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "HasMore":
+			out.HasMore = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1637,42 +3675,58 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(out *jwriter.Writer, in IDResponse) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels34(out *jwriter.Writer, in ChatHistory) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"Messages\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v55, v56 := range in.Messages {
+				if v55 > 0 {
+					out.RawByte(',')
+				}
+				(v56).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"HasMore\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasMore))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v IDResponse) MarshalJSON() ([]byte, error) {
+func (v ChatHistory) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels34(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v IDResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels15(w, v)
+func (v ChatHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels34(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *IDResponse) UnmarshalJSON(data []byte) error {
+func (v *ChatHistory) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels34(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *IDResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels15(l, v)
+func (v *ChatHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels34(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(in *jlexer.Lexer, out *IDRequest) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels35(in *jlexer.Lexer, out *ChatCursor) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1691,8 +3745,12 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(in *jlexer.Lexer,
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = int(in.Int())
+		case "Before":
+			out.Before = int(in.Int())
+		case "After":
+			out.After = int(in.Int())
+		case "Limit":
+			out.Limit = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1703,42 +3761,52 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(out *jwriter.Writer, in IDRequest) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels35(out *jwriter.Writer, in ChatCursor) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"Before\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
+		out.Int(int(in.Before))
+	}
+	{
+		const prefix string = ",\"After\":"
+		out.RawString(prefix)
+		out.Int(int(in.After))
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v IDRequest) MarshalJSON() ([]byte, error) {
+func (v ChatCursor) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels35(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v IDRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels16(w, v)
+func (v ChatCursor) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels35(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *IDRequest) UnmarshalJSON(data []byte) error {
+func (v *ChatCursor) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels35(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *IDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels16(l, v)
+func (v *ChatCursor) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels35(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(in *jlexer.Lexer, out *Chat) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels36(in *jlexer.Lexer, out *Chat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1765,6 +3833,14 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(in *jlexer.Lexer,
 			out.InterlocutorName = string(in.String())
 		case "last_message":
 			out.LastMsg = string(in.String())
+		case "last_attachment":
+			out.LastAttachment = string(in.String())
+		case "last_message_at":
+			out.LastMsgAtStr = string(in.String())
+		case "unread_count":
+			out.UnreadCount = int(in.Int())
+		case "presence":
+			(out.Presence).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -1775,7 +3851,7 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(out *jwriter.Writer, in Chat) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels36(out *jwriter.Writer, in Chat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1799,33 +3875,53 @@ func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(out *jwriter.Writ
 		out.RawString(prefix)
 		out.String(string(in.LastMsg))
 	}
+	if in.LastAttachment != "" {
+		const prefix string = ",\"last_attachment\":"
+		out.RawString(prefix)
+		out.String(string(in.LastAttachment))
+	}
+	{
+		const prefix string = ",\"last_message_at\":"
+		out.RawString(prefix)
+		out.String(string(in.LastMsgAtStr))
+	}
+	{
+		const prefix string = ",\"unread_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.UnreadCount))
+	}
+	{
+		const prefix string = ",\"presence\":"
+		out.RawString(prefix)
+		(in.Presence).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Chat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels36(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Chat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels17(w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels36(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Chat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels36(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Chat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels17(l, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels36(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(in *jlexer.Lexer, out *CartRequest) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels37(in *jlexer.Lexer, out *CartRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1858,7 +3954,7 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(out *jwriter.Writer, in CartRequest) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels37(out *jwriter.Writer, in CartRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1878,27 +3974,27 @@ func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(out *jwriter.Writ
 // MarshalJSON supports json.Marshaler interface
 func (v CartRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels37(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CartRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels18(w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels37(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CartRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels37(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CartRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels18(l, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels37(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(in *jlexer.Lexer, out *AddResponse) {
+func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels38(in *jlexer.Lexer, out *AddResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1929,7 +4025,7 @@ func easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(out *jwriter.Writer, in AddResponse) {
+func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels38(out *jwriter.Writer, in AddResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1944,23 +4040,23 @@ func easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(out *jwriter.Writ
 // MarshalJSON supports json.Marshaler interface
 func (v AddResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(&w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels38(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels19(w, v)
+	easyjsonD2b7633eEncodeGithubComFriendsInternalPkgModels38(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(&r, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels38(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels19(l, v)
+	easyjsonD2b7633eDecodeGithubComFriendsInternalPkgModels38(l, v)
 }
@@ -6,30 +6,60 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 )
 
+const (
+	OrderStatusCreated    = "created"
+	OrderStatusAccepted   = "accepted"
+	OrderStatusCooking    = "cooking"
+	OrderStatusDelivering = "delivering"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusRejected   = "rejected"
+)
+
 //easyjson:json
 type OrderRequest struct {
-	VendorID   int            `json:"-"`
-	VendorName string         `json:"-"`
-	ProductIDs []int          `json:"products"`
-	Products   []OrderProduct `json:"-"`
-	CreatedAt  time.Time      `json:"-"`
-	Address    string         `json:"address"`
-	Price      int            `json:"-"`
+	VendorID    int               `json:"-"`
+	VendorName  string            `json:"-"`
+	ProductIDs  []int             `json:"products"`
+	Items       []ProductQuantity `json:"items"`
+	Products    []OrderProduct    `json:"-"`
+	CreatedAt   time.Time         `json:"-"`
+	Address     string            `json:"address"`
+	Longitude   float64           `json:"longitude"`
+	Latitude    float64           `json:"latitude"`
+	Price       int               `json:"-"`
+	Distance    int               `json:"-"`
+	DeliveryFee int               `json:"-"`
+	DeliverAt   time.Time         `json:"deliver_at"` // zero for orders delivered as soon as possible
 }
 
 //easyjson:json
 type OrderResponse struct {
-	ID           int            `json:"id"`
-	UserID       int            `json:"user_id"`
-	VendorID     int            `json:"-"`
-	VendorName   string         `json:"vendor_name,omitempty"`
-	Products     []OrderProduct `json:"products"`
-	CreatedAt    time.Time      `json:"-"`
-	CreatedAtStr string         `json:"created_at"`
-	Address      string         `json:"address"`
-	Status       string         `json:"status"`
-	Price        int            `json:"price"`
-	Reviewed     bool           `json:"reviewed"`
+	ID             int                 `json:"id"`
+	UserID         int                 `json:"user_id"`
+	VendorID       int                 `json:"-"`
+	VendorName     string              `json:"vendor_name,omitempty"`
+	Products       []OrderProduct      `json:"products"`
+	CreatedAt      time.Time           `json:"-"`
+	CreatedAtStr   string              `json:"created_at"`
+	DeliverAt      time.Time           `json:"-"`
+	DeliverAtStr   string              `json:"deliver_at,omitempty"`
+	Address        string              `json:"address"`
+	Status         string              `json:"status"`
+	Price          int                 `json:"price"`
+	DeliveryFee    int                 `json:"delivery_fee"`
+	Reviewed       bool                `json:"reviewed"`
+	PaymentStatus  string              `json:"payment_status"`
+	PaymentIntent  string              `json:"-"`
+	RefundedAmount int                 `json:"refunded_amount"`
+	Refunds        []Refund            `json:"refunds,omitempty"`
+	CancelReason   string              `json:"cancel_reason,omitempty"`
+	History        []OrderStatusChange `json:"history,omitempty"`
+}
+
+// Total is the amount the customer pays for the order.
+func (o OrderResponse) Total() int {
+	return o.Price + o.DeliveryFee
 }
 
 type VendorOrdersResponse struct {
@@ -40,9 +70,12 @@ type VendorOrdersResponse struct {
 
 //easyjson:json
 type OrderProduct struct {
-	Picture string `json:"picture"`
-	Name    string `json:"food_name"`
-	Price   int    `json:"food_price"`
+	ID       int    `json:"id"`
+	Picture  string `json:"picture"`
+	Name     string `json:"food_name"`
+	Price    int    `json:"food_price"`
+	Quantity int    `json:"quantity"`
+	Refunded int    `json:"refunded_quantity"`
 }
 
 //easyjson:json
@@ -57,7 +90,22 @@ type IDResponse struct {
 
 //easyjson:json
 type OrderStatusRequest struct {
-	Status string `json:"status"`
+	Status  string `json:"status"`
+	Comment string `json:"comment"`
+}
+
+//easyjson:json
+type OrderCancelRequest struct {
+	Reason string `json:"reason"`
+}
+
+//easyjson:json
+type OrderStatusChange struct {
+	Status       string    `json:"status"`
+	UserID       string    `json:"user_id"`
+	Comment      string    `json:"comment"`
+	ChangedAt    time.Time `json:"-"`
+	ChangedAtStr string    `json:"changed_at"`
 }
 
 //easyjson:json
@@ -67,6 +115,7 @@ type OrderStatusMessage struct {
 	VendorName    string `json:"vendor_name"`
 	VendorPicture string `json:"vendor_picture"`
 	Status        string `json:"order_status"`
+	Comment       string `json:"comment,omitempty"`
 }
 
 func (o *OrderRequest) Sanitize() {
@@ -75,7 +124,13 @@ func (o *OrderRequest) Sanitize() {
 	o.Address = p.Sanitize(o.Address)
 }
 
+func (c *OrderCancelRequest) Sanitize() {
+	p := bluemonday.UGCPolicy()
+	c.Reason = p.Sanitize(c.Reason)
+}
+
 func (s *OrderStatusRequest) Sanitize() {
 	p := bluemonday.UGCPolicy()
 	s.Status = p.Sanitize(s.Status)
+	s.Comment = p.Sanitize(s.Comment)
 }
@@ -0,0 +1,25 @@
+package models
+
+const (
+	PaymentStatusPending  = "pending"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusFailed   = "failed"
+	PaymentStatusRefunded = "refunded"
+)
+
+//easyjson:json
+type PaymentIntent struct {
+	ID       string `json:"id"`
+	OrderID  int    `json:"order_id"`
+	Amount   int    `json:"amount"`
+	Refunded int    `json:"refunded"`
+	Status   string `json:"status"`
+}
+
+//easyjson:json
+type PaymentEvent struct {
+	IntentID string `json:"intent_id"`
+	OrderID  int    `json:"order_id"`
+	Amount   int    `json:"amount"`
+	Status   string `json:"status"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+//easyjson:json
+type RefundRequest struct {
+	Reason string       `json:"reason"`
+	Items  []RefundItem `json:"items"`
+}
+
+//easyjson:json
+type RefundItem struct {
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+//easyjson:json
+type Refund struct {
+	ID      int `json:"id"`
+	OrderID int `json:"order_id"`
+	// ItemID is empty for refunds of the whole order.
+	ItemID       int       `json:"item_id,omitempty"`
+	Quantity     int       `json:"quantity,omitempty"`
+	Amount       int       `json:"amount"`
+	Reason       string    `json:"reason"`
+	InitiatorID  string    `json:"initiator_id"`
+	CreatedAt    time.Time `json:"-"`
+	CreatedAtStr string    `json:"created_at"`
+}
+
+func (r *RefundRequest) Sanitize() {
+	p := bluemonday.UGCPolicy()
+	r.Reason = p.Sanitize(r.Reason)
+}
@@ -12,8 +12,10 @@ type Vendor struct {
 	Picture     string    `json:"picture"`
 	Longitude   float32   `json:"longitude"`
 	Latitude    float32   `json:"latitude"`
-	Radius      int       `json:"distance"`
+	Radius      int       `json:"distance"` // service radius in kilometers
 	Categories  []string  `json:"categories"`
+	DistanceTo  int       `json:"distance_to,omitempty"` // distance from the given point in meters
+	IsOpenNow   bool      `json:"is_open_now"`
 }
 
 //easyjson:json
@@ -24,6 +26,19 @@ type Product struct {
 	Description string `json:"description"`
 	Price       int    `json:"food_price"`
 	VendorID    int    `json:"vendor_id"`
+	Quantity    int    `json:"quantity,omitempty"`
+}
+
+//easyjson:json
+type DeliveryFeeTier struct {
+	MaxDistance int `json:"max_distance"`
+	Fee         int `json:"fee"`
+}
+
+//easyjson:json
+type DeliverySettings struct {
+	MinOrderAmount int               `json:"min_order_amount"`
+	FeeTiers       []DeliveryFeeTier `json:"fee_tiers"`
 }
 
 //easyjson:json
@@ -51,3 +66,32 @@ func (p *Product) Sanitize() {
 	p.Name = pol.Sanitize(p.Name)
 	p.Description = pol.Sanitize(p.Description)
 }
+
+const GeoJSONMultiPolygon = "MultiPolygon"
+
+//easyjson:json
+type DeliveryZone struct {
+	Type        string           `json:"type"`
+	Coordinates [][][][2]float64 `json:"coordinates"` // polygons of [longitude, latitude] rings
+}
+
+//easyjson:json
+type OpeningHours struct {
+	Weekday int    `json:"weekday"` // 0 is Sunday
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"` // closes before opens for overnight hours
+}
+
+//easyjson:json
+type Schedule struct {
+	TimeZone  string         `json:"time_zone"`
+	Hours     []OpeningHours `json:"hours"`
+	Holidays  []string       `json:"holidays"`
+	Paused    bool           `json:"paused"`
+	IsOpenNow bool           `json:"is_open_now"`
+}
+
+//easyjson:json
+type PauseRequest struct {
+	Paused bool `json:"paused"`
+}
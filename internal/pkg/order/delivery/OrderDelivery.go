@@ -3,6 +3,7 @@ package delivery
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,7 +17,6 @@ import (
 	ownErr "github.com/friends/pkg/error"
 	log "github.com/friends/pkg/logger"
 	"github.com/gorilla/mux"
-	"github.com/gorilla/websocket"
 )
 
 type OrderDelivery struct {
@@ -36,6 +36,18 @@ func New(
 }
 
 func (o OrderDelivery) AddOrder(w http.ResponseWriter, r *http.Request) {
+	o.writeCreatedOrder(w, r, order.Usecase.AddOrder)
+}
+
+func (o OrderDelivery) Checkout(w http.ResponseWriter, r *http.Request) {
+	o.writeCreatedOrder(w, r, order.Usecase.Checkout)
+}
+
+// writeCreatedOrder decodes the order request, creates the order with the
+// given usecase method and writes its id.
+func (o OrderDelivery) writeCreatedOrder(
+	w http.ResponseWriter, r *http.Request, create func(order.Usecase, string, models.OrderRequest) (int, error),
+) {
 	var err error
 	defer func() {
 		if err != nil {
@@ -60,7 +72,7 @@ func (o OrderDelivery) AddOrder(w http.ResponseWriter, r *http.Request) {
 
 	order.CreatedAt = time.Now()
 
-	orderID, err := o.orderUsecase.AddOrder(userID, order)
+	orderID, err := create(o.orderUsecase, userID, order)
 	if err != nil {
 		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusConflict)
 		return
@@ -75,6 +87,10 @@ func (o OrderDelivery) AddOrder(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+
+	if order.DeliverAt.IsZero() {
+		err = o.orderUsecase.NotifyNewOrder(orderID)
+	}
 }
 
 func (o OrderDelivery) GetOrder(w http.ResponseWriter, r *http.Request) {
@@ -112,6 +128,41 @@ func (o OrderDelivery) GetOrder(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (o OrderDelivery) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	orderID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no id in path")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	history, err := o.orderUsecase.GetOrderHistory(userID, orderID)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(history)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (o OrderDelivery) GetUserOrders(w http.ResponseWriter, r *http.Request) {
 	var err error
 	defer func() {
@@ -230,46 +281,128 @@ func (o OrderDelivery) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = o.orderUsecase.UpdateOrderStatus(orderID, status.Status)
+	change := models.OrderStatusChange{
+		Status:    status.Status,
+		UserID:    partnerID,
+		Comment:   status.Comment,
+		ChangedAt: time.Now(),
+	}
+
+	err = o.orderUsecase.UpdateOrderStatus(vendorID, orderID, change, order.ActorPartner)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusConflict)
 		return
 	}
 
+	// The status is already changed, so notification errors are only logged.
 	clientID, err := o.orderUsecase.GetUserIDFromOrder(orderIDInt)
 	if err != nil {
-		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
 		return
 	}
 
-	wsConn, ok := o.websocketPool.Get(clientID)
+	err = o.notifyStatus(clientID, vendorID, models.OrderStatusMessage{
+		Type:    "status",
+		OrderID: orderID,
+		Status:  status.Status,
+	})
+}
+
+func (o OrderDelivery) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
 	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	vendor, err := o.vendorUsecase.GetVendorInfo(vendorID)
+	orderID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no order id in path")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	orderIDInt, err := strconv.Atoi(orderID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	cancelRequest := models.OrderCancelRequest{}
+	err = json.NewDecoder(r.Body).Decode(&cancelRequest)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cancelRequest.Sanitize()
+
+	err = o.orderUsecase.CancelOrder(userID, orderID, cancelRequest.Reason)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusConflict)
+		return
+	}
+
+	// The order is already cancelled, so notification errors are only logged.
+	vendorID, err := o.orderUsecase.GetVendorIDFromOrder(orderIDInt)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	orderMessage := models.OrderStatusMessage{
-		Type:          "status",
-		OrderID:       orderID,
-		VendorName:    vendor.Name,
-		VendorPicture: vendor.Picture,
-		Status:        status.Status,
+	partnerID, err := o.vendorUsecase.GetVendorOwner(vendorID)
+	if err != nil {
+		return
 	}
 
+	err = o.notifyStatus(partnerID, strconv.Itoa(vendorID), models.OrderStatusMessage{
+		Type:    "status",
+		OrderID: orderID,
+		Status:  models.OrderStatusCancelled,
+		Comment: cancelRequest.Reason,
+	})
+}
+
+// notifyStatus sends the order status change to the user over websocket.
+func (o OrderDelivery) notifyStatus(userID string, vendorID string, orderMessage models.OrderStatusMessage) error {
+	vendor, err := o.vendorUsecase.GetVendorInfo(vendorID)
+	if err != nil {
+		return err
+	}
+
+	orderMessage.VendorName = vendor.Name
+	orderMessage.VendorPicture = vendor.Picture
+
 	msgJSON, err := json.Marshal(orderMessage)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+
+	return o.websocketPool.Send(userID, msgJSON)
+}
+
+func (o OrderDelivery) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	err = wsConn.WriteMessage(websocket.TextMessage, msgJSON)
+	err = o.orderUsecase.HandlePaymentWebhook(payload, r.Header.Get(configs.PaymentSignatureHeader))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
 		return
 	}
 }
@@ -13,9 +13,12 @@ import (
 	"time"
 
 	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker"
+	"github.com/friends/internal/pkg/broker/memory"
 	"github.com/friends/internal/pkg/middleware"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/order"
+	"github.com/friends/internal/pkg/payment"
 	"github.com/friends/internal/pkg/vendors"
 	websocketpool "github.com/friends/internal/pkg/websocketPool"
 	ownErr "github.com/friends/pkg/error"
@@ -57,10 +60,14 @@ var (
 	vendorID = "15"
 
 	testStatus = models.OrderStatusRequest{
-		Status: "ready",
+		Status: models.OrderStatusAccepted,
 	}
 
-	wsPool = websocketpool.NewWebsocketPool()
+	testCancel = models.OrderCancelRequest{
+		Reason: "changed my mind",
+	}
+
+	wsPool = websocketpool.NewWebsocketPool(memory.New())
 )
 
 func TestAddOrderSuccess(t *testing.T) {
@@ -70,6 +77,7 @@ func TestAddOrderSuccess(t *testing.T) {
 	mockOrderUsecase := order.NewMockUsecase(ctrl)
 
 	mockOrderUsecase.EXPECT().AddOrder(strconv.Itoa(response.UserID), gomock.Any()).Return(response.ID, nil)
+	mockOrderUsecase.EXPECT().NotifyNewOrder(response.ID).Return(nil)
 
 	orderJson, _ := json.Marshal(&testOrder)
 	body := bytes.NewReader(orderJson)
@@ -154,6 +162,66 @@ func TestAddOrderNoUser(t *testing.T) {
 	}
 }
 
+func TestCheckoutSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().Checkout(strconv.Itoa(response.UserID), gomock.Any()).Return(response.ID, nil)
+	mockOrderUsecase.EXPECT().NotifyNewOrder(response.ID).Return(nil)
+
+	body := bytes.NewReader([]byte(`{"address":"test addr"}`))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/orders/checkout", body)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.Checkout(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+
+	expectedResp := models.IDResponse{
+		ID: response.ID,
+	}
+	var resp models.IDResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !reflect.DeepEqual(expectedResp, resp) {
+		t.Errorf("expected: %v\n got: %v", expectedResp, resp)
+	}
+}
+
+func TestCheckoutEmptyCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().Checkout(strconv.Itoa(response.UserID), gomock.Any()).Return(0, ownErr.NewClientError(dbError))
+
+	body := bytes.NewReader([]byte(`{"address":"test addr"}`))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/orders/checkout", body)
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.Checkout(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusConflict
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
 func TestGetOrderSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -239,6 +307,72 @@ func TestGetOrderNoUser(t *testing.T) {
 	}
 }
 
+func TestGetOrderHistorySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	history := []models.OrderStatusChange{
+		{
+			Status:       models.OrderStatusCreated,
+			UserID:       strconv.Itoa(response.UserID),
+			ChangedAtStr: time.Now().Format(configs.TimeFormat),
+		},
+	}
+
+	mockOrderUsecase.EXPECT().GetOrderHistory(strconv.Itoa(response.UserID), strconv.Itoa(response.ID)).Return(history, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/orders/10/history", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.GetOrderHistory(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+
+	var resp []models.OrderStatusChange
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !reflect.DeepEqual(history, resp) {
+		t.Errorf("expected: %v\n got: %v", history, resp)
+	}
+}
+
+func TestGetOrderHistoryNotParticipant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().
+		GetOrderHistory(strconv.Itoa(response.UserID), strconv.Itoa(response.ID)).
+		Return(nil, ownErr.NewClientError(dbError))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/orders/10/history", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.GetOrderHistory(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusBadRequest
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
 func TestGetUserOrdersSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -430,8 +564,9 @@ func TestUpdateOrderStatusSuccess(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 
 	mockVendorUsecase.EXPECT().CheckVendorOwner(strconv.Itoa(response.UserID), vendorID).Times(1).Return(nil)
-	mockOrderUsecase.EXPECT().UpdateOrderStatus(strconv.Itoa(response.ID), testStatus.Status).Times(1).Return(nil)
+	mockOrderUsecase.EXPECT().UpdateOrderStatus(vendorID, strconv.Itoa(response.ID), gomock.Any(), order.ActorPartner).Times(1).Return(nil)
 	mockOrderUsecase.EXPECT().GetUserIDFromOrder(response.ID).Times(1).Return("0", nil)
+	mockVendorUsecase.EXPECT().GetVendorInfo(vendorID).Times(1).Return(models.Vendor{Name: "test"}, nil)
 
 	statusJson, _ := json.Marshal(&testStatus)
 	body := bytes.NewReader(statusJson)
@@ -459,7 +594,7 @@ func TestUpdateOrderStatusError(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 
 	mockVendorUsecase.EXPECT().CheckVendorOwner(strconv.Itoa(response.UserID), vendorID).Times(1).Return(nil)
-	mockOrderUsecase.EXPECT().UpdateOrderStatus(strconv.Itoa(response.ID), testStatus.Status).Times(1).Return(dbError)
+	mockOrderUsecase.EXPECT().UpdateOrderStatus(vendorID, strconv.Itoa(response.ID), gomock.Any(), order.ActorPartner).Times(1).Return(dbError)
 
 	statusJson, _ := json.Marshal(&testStatus)
 	body := bytes.NewReader(statusJson)
@@ -479,6 +614,196 @@ func TestUpdateOrderStatusError(t *testing.T) {
 	}
 }
 
+func TestUpdateOrderStatusWrongTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(strconv.Itoa(response.UserID), vendorID).Times(1).Return(nil)
+	mockOrderUsecase.EXPECT().
+		UpdateOrderStatus(vendorID, strconv.Itoa(response.ID), gomock.Any(), order.ActorPartner).
+		Times(1).Return(ownErr.NewClientError(order.ErrWrongStatusTransition))
+
+	statusJson, _ := json.Marshal(&testStatus)
+	body := bytes.NewReader(statusJson)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/orders", body)
+	r = mux.SetURLVars(r, map[string]string{"vendorID": vendorID, "id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := New(mockOrderUsecase, mockVendorUsecase, wsPool)
+
+	handler.UpdateOrderStatus(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusConflict
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func TestUpdateOrderStatusNotifyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+	mockBroker := broker.NewMockBroker(ctrl)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(strconv.Itoa(response.UserID), vendorID).Times(1).Return(nil)
+	mockOrderUsecase.EXPECT().UpdateOrderStatus(vendorID, strconv.Itoa(response.ID), gomock.Any(), order.ActorPartner).Times(1).Return(nil)
+	mockOrderUsecase.EXPECT().GetUserIDFromOrder(response.ID).Times(1).Return("0", nil)
+	mockVendorUsecase.EXPECT().GetVendorInfo(vendorID).Times(1).Return(models.Vendor{Name: "test"}, nil)
+	mockBroker.EXPECT().NextStreamID("0").Times(1).Return(int64(1), nil)
+	mockBroker.EXPECT().Publish("0", gomock.Any()).Times(1).Return(fmt.Errorf("broker error"))
+
+	statusJson, _ := json.Marshal(&testStatus)
+	body := bytes.NewReader(statusJson)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/orders", body)
+	r = mux.SetURLVars(r, map[string]string{"vendorID": vendorID, "id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := New(mockOrderUsecase, mockVendorUsecase, websocketpool.NewWebsocketPool(mockBroker))
+
+	handler.UpdateOrderStatus(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func TestCancelOrderSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	vendorIDInt, _ := strconv.Atoi(vendorID)
+
+	mockOrderUsecase.EXPECT().CancelOrder(strconv.Itoa(response.UserID), strconv.Itoa(response.ID), testCancel.Reason).Times(1).Return(nil)
+	mockOrderUsecase.EXPECT().GetVendorIDFromOrder(response.ID).Times(1).Return(vendorIDInt, nil)
+	mockVendorUsecase.EXPECT().GetVendorOwner(vendorIDInt).Times(1).Return("0", nil)
+	mockVendorUsecase.EXPECT().GetVendorInfo(vendorID).Times(1).Return(models.Vendor{Name: "test"}, nil)
+
+	cancelJson, _ := json.Marshal(&testCancel)
+	body := bytes.NewReader(cancelJson)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/orders/10/cancel", body)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := New(mockOrderUsecase, mockVendorUsecase, wsPool)
+
+	handler.CancelOrder(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func TestCancelOrderTooLate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	mockOrderUsecase.EXPECT().
+		CancelOrder(strconv.Itoa(response.UserID), strconv.Itoa(response.ID), testCancel.Reason).
+		Times(1).Return(ownErr.NewClientError(order.ErrWrongStatusTransition))
+
+	cancelJson, _ := json.Marshal(&testCancel)
+	body := bytes.NewReader(cancelJson)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/orders/10/cancel", body)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.CancelOrder(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusConflict
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
+func TestPaymentWebhookSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	payload := []byte(`{"intent_id":"pi_1","status":"paid"}`)
+	mockOrderUsecase.EXPECT().HandlePaymentWebhook(payload, "signature").Times(1).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/payments/webhook", bytes.NewReader(payload))
+	r.Header.Set(configs.PaymentSignatureHeader, "signature")
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.PaymentWebhook(w, r)
+
+	expectedCode := http.StatusOK
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+func TestPaymentWebhookWrongSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+
+	payload := []byte(`{"intent_id":"pi_1","status":"paid"}`)
+	mockOrderUsecase.EXPECT().HandlePaymentWebhook(payload, "").Times(1).Return(ownErr.NewClientError(payment.ErrWrongSignature))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/payments/webhook", bytes.NewReader(payload))
+
+	handler := OrderDelivery{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	handler.PaymentWebhook(w, r)
+
+	expectedCode := http.StatusBadRequest
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+func TestCancelOrderBadJson(t *testing.T) {
+	body := bytes.NewReader([]byte(`{"reason":"`))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/orders/10/cancel", body)
+	r = mux.SetURLVars(r, map[string]string{"id": strconv.Itoa(response.ID)})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), strconv.Itoa(response.UserID))
+
+	handler := OrderDelivery{}
+
+	handler.CancelOrder(w, r.WithContext(ctx))
+
+	expectedCode := http.StatusBadRequest
+	if w.Code != expectedCode {
+		t.Errorf("expected: %v\n got: %v", expectedCode, w.Code)
+	}
+}
+
 func TestUpdateOrderStatusNoOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
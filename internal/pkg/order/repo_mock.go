@@ -0,0 +1,314 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/friends/internal/pkg/order (interfaces: Repository)
+
+// Package order is a generated GoMock package.
+package order
+
+import (
+	models "github.com/friends/internal/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddOrder mocks base method
+func (m *MockRepository) AddOrder(arg0 string, arg1 models.OrderRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrder", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrder indicates an expected call of AddOrder
+func (mr *MockRepositoryMockRecorder) AddOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockRepository)(nil).AddOrder), arg0, arg1)
+}
+
+// AddOrderFromCart mocks base method
+func (m *MockRepository) AddOrderFromCart(arg0 string, arg1 models.OrderRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderFromCart", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrderFromCart indicates an expected call of AddOrderFromCart
+func (mr *MockRepositoryMockRecorder) AddOrderFromCart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderFromCart", reflect.TypeOf((*MockRepository)(nil).AddOrderFromCart), arg0, arg1)
+}
+
+// CancelOrder mocks base method
+func (m *MockRepository) CancelOrder(arg0, arg1 string, arg2 models.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder
+func (mr *MockRepositoryMockRecorder) CancelOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockRepository)(nil).CancelOrder), arg0, arg1, arg2)
+}
+
+// CheckOrderByUser mocks base method
+func (m *MockRepository) CheckOrderByUser(arg0, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrderByUser", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckOrderByUser indicates an expected call of CheckOrderByUser
+func (mr *MockRepositoryMockRecorder) CheckOrderByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrderByUser", reflect.TypeOf((*MockRepository)(nil).CheckOrderByUser), arg0, arg1)
+}
+
+// GetOrder mocks base method
+func (m *MockRepository) GetOrder(arg0 string) (models.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", arg0)
+	ret0, _ := ret[0].(models.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder
+func (mr *MockRepositoryMockRecorder) GetOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockRepository)(nil).GetOrder), arg0)
+}
+
+// GetOrderHistory mocks base method
+func (m *MockRepository) GetOrderHistory(arg0 string) ([]models.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderHistory", arg0)
+	ret0, _ := ret[0].([]models.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderHistory indicates an expected call of GetOrderHistory
+func (mr *MockRepositoryMockRecorder) GetOrderHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderHistory", reflect.TypeOf((*MockRepository)(nil).GetOrderHistory), arg0)
+}
+
+// GetOrderStatus mocks base method
+func (m *MockRepository) GetOrderStatus(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatus", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatus indicates an expected call of GetOrderStatus
+func (mr *MockRepositoryMockRecorder) GetOrderStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockRepository)(nil).GetOrderStatus), arg0)
+}
+
+// GetProductsFromOrder mocks base method
+func (m *MockRepository) GetProductsFromOrder(arg0 *models.OrderResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsFromOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetProductsFromOrder indicates an expected call of GetProductsFromOrder
+func (mr *MockRepositoryMockRecorder) GetProductsFromOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsFromOrder", reflect.TypeOf((*MockRepository)(nil).GetProductsFromOrder), arg0)
+}
+
+// GetUnrefundedOrders mocks base method
+func (m *MockRepository) GetUnrefundedOrders() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnrefundedOrders")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnrefundedOrders indicates an expected call of GetUnrefundedOrders
+func (mr *MockRepositoryMockRecorder) GetUnrefundedOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnrefundedOrders", reflect.TypeOf((*MockRepository)(nil).GetUnrefundedOrders))
+}
+
+// GetUserIDFromOrder mocks base method
+func (m *MockRepository) GetUserIDFromOrder(arg0 int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromOrder", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromOrder indicates an expected call of GetUserIDFromOrder
+func (mr *MockRepositoryMockRecorder) GetUserIDFromOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromOrder", reflect.TypeOf((*MockRepository)(nil).GetUserIDFromOrder), arg0)
+}
+
+// GetUserOrders mocks base method
+func (m *MockRepository) GetUserOrders(arg0 string) ([]models.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", arg0)
+	ret0, _ := ret[0].([]models.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders
+func (mr *MockRepositoryMockRecorder) GetUserOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*MockRepository)(nil).GetUserOrders), arg0)
+}
+
+// GetVendorIDFromOrder mocks base method
+func (m *MockRepository) GetVendorIDFromOrder(arg0 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorIDFromOrder", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorIDFromOrder indicates an expected call of GetVendorIDFromOrder
+func (mr *MockRepositoryMockRecorder) GetVendorIDFromOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorIDFromOrder", reflect.TypeOf((*MockRepository)(nil).GetVendorIDFromOrder), arg0)
+}
+
+// GetVendorOrders mocks base method
+func (m *MockRepository) GetVendorOrders(arg0 string) ([]models.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorOrders", arg0)
+	ret0, _ := ret[0].([]models.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorOrders indicates an expected call of GetVendorOrders
+func (mr *MockRepositoryMockRecorder) GetVendorOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorOrders", reflect.TypeOf((*MockRepository)(nil).GetVendorOrders), arg0)
+}
+
+// GetVendorOrdersIDs mocks base method
+func (m *MockRepository) GetVendorOrdersIDs(arg0 string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorOrdersIDs", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorOrdersIDs indicates an expected call of GetVendorOrdersIDs
+func (mr *MockRepositoryMockRecorder) GetVendorOrdersIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorOrdersIDs", reflect.TypeOf((*MockRepository)(nil).GetVendorOrdersIDs), arg0)
+}
+
+// ReleaseScheduledOrders mocks base method
+func (m *MockRepository) ReleaseScheduledOrders(arg0 time.Time) ([]models.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseScheduledOrders", arg0)
+	ret0, _ := ret[0].([]models.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseScheduledOrders indicates an expected call of ReleaseScheduledOrders
+func (mr *MockRepositoryMockRecorder) ReleaseScheduledOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseScheduledOrders", reflect.TypeOf((*MockRepository)(nil).ReleaseScheduledOrders), arg0)
+}
+
+// SetOrderReviewStatus mocks base method
+func (m *MockRepository) SetOrderReviewStatus(arg0 int, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderReviewStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderReviewStatus indicates an expected call of SetOrderReviewStatus
+func (mr *MockRepositoryMockRecorder) SetOrderReviewStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderReviewStatus", reflect.TypeOf((*MockRepository)(nil).SetOrderReviewStatus), arg0, arg1)
+}
+
+// SetPaymentIntent mocks base method
+func (m *MockRepository) SetPaymentIntent(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentIntent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentIntent indicates an expected call of SetPaymentIntent
+func (mr *MockRepositoryMockRecorder) SetPaymentIntent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentIntent", reflect.TypeOf((*MockRepository)(nil).SetPaymentIntent), arg0, arg1)
+}
+
+// UpdateOrderStatus mocks base method
+func (m *MockRepository) UpdateOrderStatus(arg0, arg1 string, arg2 models.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus
+func (mr *MockRepositoryMockRecorder) UpdateOrderStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockRepository)(nil).UpdateOrderStatus), arg0, arg1, arg2)
+}
+
+// UpdatePaymentStatus mocks base method
+func (m *MockRepository) UpdatePaymentStatus(arg0, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus
+func (mr *MockRepositoryMockRecorder) UpdatePaymentStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockRepository)(nil).UpdatePaymentStatus), arg0, arg1, arg2)
+}
@@ -1,17 +1,30 @@
 package order
 
-import "github.com/friends/internal/pkg/models"
+import (
+	"time"
 
+	"github.com/friends/internal/pkg/models"
+)
+
+//go:generate mockgen -destination=./repo_mock.go -package=order github.com/friends/internal/pkg/order Repository
 type Repository interface {
 	AddOrder(userID string, order models.OrderRequest) (int, error)
+	AddOrderFromCart(userID string, order models.OrderRequest) (int, error)
 	GetOrder(orderID string) (models.OrderResponse, error)
 	GetUserOrders(userID string) ([]models.OrderResponse, error)
 	CheckOrderByUser(userID string, orderID string) bool
 	GetVendorOrders(vendorID string) ([]models.OrderResponse, error)
 	GetVendorOrdersIDs(vendorID string) ([]int, error)
-	UpdateOrderStatus(orderID string, status string) error
+	UpdateOrderStatus(orderID string, from string, change models.OrderStatusChange) error
+	GetOrderHistory(orderID string) ([]models.OrderStatusChange, error)
+	CancelOrder(orderID string, from string, change models.OrderStatusChange) error
+	GetOrderStatus(orderID string) (string, error)
+	SetPaymentIntent(orderID int, intentID string) error
+	UpdatePaymentStatus(intentID string, from string, status string) (bool, error)
 	GetProductsFromOrder(order *models.OrderResponse) error
 	GetVendorIDFromOrder(orderID int) (int, error)
 	SetOrderReviewStatus(orderID int, status bool) error
 	GetUserIDFromOrder(orderID int) (string, error)
+	ReleaseScheduledOrders(before time.Time) ([]models.OrderResponse, error)
+	GetUnrefundedOrders() ([]string, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -11,6 +12,8 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/order"
+	"github.com/lib/pq"
 )
 
 var (
@@ -21,41 +24,52 @@ var (
 	dbError = fmt.Errorf("db error")
 
 	request = models.OrderRequest{
-		VendorID:   5,
-		VendorName: "test",
-		CreatedAt:  time.Now(),
-		Address:    "test addr",
-		Price:      1000,
+		VendorID:    5,
+		VendorName:  "test",
+		CreatedAt:   time.Now(),
+		Address:     "test addr",
+		Price:       1000,
+		Distance:    1200,
+		DeliveryFee: 99,
 		Products: []models.OrderProduct{
 			{
-				Name:    "test product",
-				Price:   1000,
-				Picture: "test.png",
+				Name:     "test product",
+				Price:    1000,
+				Picture:  "test.png",
+				Quantity: 1,
 			},
 		},
 	}
 
 	response = models.OrderResponse{
-		ID:           10,
-		UserID:       50,
-		VendorID:     20,
-		VendorName:   "test",
-		CreatedAt:    time.Now(),
-		CreatedAtStr: time.Now().Format(configs.TimeFormat),
-		Address:      "test addr",
-		Status:       "ready",
-		Price:        1000,
-		Reviewed:     false,
+		ID:             10,
+		UserID:         50,
+		VendorID:       20,
+		VendorName:     "test",
+		CreatedAt:      time.Now(),
+		CreatedAtStr:   time.Now().Format(configs.TimeFormat),
+		Address:        "test addr",
+		Status:         "ready",
+		Price:          1000,
+		DeliveryFee:    99,
+		Reviewed:       false,
+		PaymentStatus:  models.PaymentStatusPaid,
+		RefundedAmount: 300,
 		Products: []models.OrderProduct{
 			{
-				Name:    "test1",
-				Price:   400,
-				Picture: "1.jpg",
+				ID:       1,
+				Name:     "test1",
+				Price:    400,
+				Picture:  "1.jpg",
+				Quantity: 1,
 			},
 			{
-				Name:    "test2",
-				Price:   600,
-				Picture: "2.jpg",
+				ID:       2,
+				Name:     "test2",
+				Price:    300,
+				Picture:  "2.jpg",
+				Quantity: 2,
+				Refunded: 1,
 			},
 		},
 	}
@@ -78,12 +92,20 @@ func TestAddOrder(t *testing.T) {
 
 	mock.
 		ExpectQuery("INSERT INTO orders").
-		WithArgs(userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price).
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
 		WillReturnRows(rows)
 
 	mock.
 		ExpectExec("INSERT INTO products_in_order").
-		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture).
+		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture, request.Products[0].Quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(orderID, models.OrderStatusCreated, userID, request.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
@@ -103,7 +125,10 @@ func TestAddOrder(t *testing.T) {
 
 	mock.
 		ExpectQuery("INSERT INTO orders").
-		WithArgs(userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price).
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
 		WillReturnError(dbError)
 
 	mock.ExpectRollback()
@@ -151,12 +176,15 @@ func TestAddOrderBadQuery(t *testing.T) {
 
 	mock.
 		ExpectQuery("INSERT INTO orders").
-		WithArgs(userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price).
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
 		WillReturnRows(rows)
 
 	mock.
 		ExpectExec("INSERT INTO products_in_order").
-		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture).
+		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture, request.Products[0].Quantity).
 		WillReturnError(dbError)
 
 	mock.ExpectRollback()
@@ -189,12 +217,20 @@ func TestAddOrderCommitError(t *testing.T) {
 
 	mock.
 		ExpectQuery("INSERT INTO orders").
-		WithArgs(userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price).
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
 		WillReturnRows(rows)
 
 	mock.
 		ExpectExec("INSERT INTO products_in_order").
-		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture).
+		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture, request.Products[0].Quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(orderID, models.OrderStatusCreated, userID, request.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit().WillReturnError(dbError)
@@ -213,6 +249,100 @@ func TestAddOrderCommitError(t *testing.T) {
 	}
 }
 
+func TestAddOrderFromCart(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	cartRequest := request
+	cartRequest.Items = []models.ProductQuantity{{ProductID: 3, Quantity: 1}}
+
+	// good query
+	mock.ExpectBegin()
+
+	orderID := 100
+	rows := mock.NewRows([]string{"orderID"}).AddRow(orderID)
+
+	mock.
+		ExpectQuery("INSERT INTO orders").
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
+		WillReturnRows(rows)
+
+	mock.
+		ExpectExec("INSERT INTO products_in_order").
+		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture, request.Products[0].Quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(orderID, models.OrderStatusCreated, userID, request.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("DELETE FROM carts").
+		WithArgs(userID, pq.Array([]int{3})).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectCommit()
+
+	id, err := repo.AddOrderFromCart(userID, cartRequest)
+
+	if id != orderID {
+		t.Errorf("expected: %v\n got: %v", orderID, id)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// cart error
+	mock.ExpectBegin()
+
+	rows = mock.NewRows([]string{"orderID"}).AddRow(orderID)
+
+	mock.
+		ExpectQuery("INSERT INTO orders").
+		WithArgs(
+			userID, request.VendorID, request.VendorName, request.CreatedAt, request.Address, request.Price,
+			request.Distance, request.DeliveryFee, sql.NullTime{}, true,
+		).
+		WillReturnRows(rows)
+
+	mock.
+		ExpectExec("INSERT INTO products_in_order").
+		WithArgs(orderID, request.Products[0].Name, request.Products[0].Price, request.Products[0].Picture, request.Products[0].Quantity).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(orderID, models.OrderStatusCreated, userID, request.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("DELETE FROM carts").
+		WithArgs(userID, pq.Array([]int{3})).
+		WillReturnError(dbError)
+
+	mock.ExpectRollback()
+
+	id, err = repo.AddOrderFromCart(userID, cartRequest)
+
+	if id != 0 {
+		t.Errorf("expected: %v\n got: %v", 0, id)
+	}
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
 func TestGetOrder(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -222,8 +352,8 @@ func TestGetOrder(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "vendorID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.VendorID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "vendorID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "cancel_reason", "payment_status", "payment_intent", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.VendorID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.CancelReason, response.PaymentStatus, response.PaymentIntent, response.RefundedAmount, nil)
 
 	// good query
 	mock.
@@ -231,13 +361,13 @@ func TestGetOrder(t *testing.T) {
 		WithArgs(strconv.Itoa(response.ID)).
 		WillReturnRows(rows)
 
-	productRows := mock.NewRows([]string{"productName", "price", "picture"})
+	productRows := mock.NewRows([]string{"id", "productName", "price", "picture", "quantity", "refunded"})
 	for _, prod := range response.Products {
-		productRows.AddRow(prod.Name, prod.Price, prod.Picture)
+		productRows.AddRow(prod.ID, prod.Name, prod.Price, prod.Picture, prod.Quantity, prod.Refunded)
 	}
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnRows(productRows)
 
@@ -278,8 +408,8 @@ func TestGetOrderProductsError(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "vendorID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.VendorID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "vendorID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "cancel_reason", "payment_status", "payment_intent", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.VendorID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.CancelReason, response.PaymentStatus, response.PaymentIntent, response.RefundedAmount, nil)
 
 	// bad query
 	mock.
@@ -288,7 +418,7 @@ func TestGetOrderProductsError(t *testing.T) {
 		WillReturnRows(rows)
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnError(dbError)
 
@@ -313,8 +443,8 @@ func TestGetVendorOrders(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "payment_status", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.PaymentStatus, response.RefundedAmount, nil)
 
 	// good query
 	mock.
@@ -322,13 +452,13 @@ func TestGetVendorOrders(t *testing.T) {
 		WithArgs(strconv.Itoa(response.VendorID)).
 		WillReturnRows(rows)
 
-	productRows := mock.NewRows([]string{"productName", "price", "picture"})
+	productRows := mock.NewRows([]string{"id", "productName", "price", "picture", "quantity", "refunded"})
 	for _, prod := range response.Products {
-		productRows.AddRow(prod.Name, prod.Price, prod.Picture)
+		productRows.AddRow(prod.ID, prod.Name, prod.Price, prod.Picture, prod.Quantity, prod.Refunded)
 	}
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnRows(productRows)
 
@@ -391,8 +521,8 @@ func TestGetVendorOrdersProductsError(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "payment_status", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.PaymentStatus, response.RefundedAmount, nil)
 
 	// bad query
 	mock.
@@ -401,7 +531,7 @@ func TestGetVendorOrdersProductsError(t *testing.T) {
 		WillReturnRows(rows)
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnError(dbError)
 
@@ -425,8 +555,8 @@ func TestGetUserOrders(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "payment_status", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.PaymentStatus, response.RefundedAmount, nil)
 
 	// good query
 	mock.
@@ -434,13 +564,13 @@ func TestGetUserOrders(t *testing.T) {
 		WithArgs(strconv.Itoa(response.UserID)).
 		WillReturnRows(rows)
 
-	productRows := mock.NewRows([]string{"productName", "price", "picture"})
+	productRows := mock.NewRows([]string{"id", "productName", "price", "picture", "quantity", "refunded"})
 	for _, prod := range response.Products {
-		productRows.AddRow(prod.Name, prod.Price, prod.Picture)
+		productRows.AddRow(prod.ID, prod.Name, prod.Price, prod.Picture, prod.Quantity, prod.Refunded)
 	}
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnRows(productRows)
 
@@ -502,8 +632,8 @@ func TestGetUserOrdersProductsError(t *testing.T) {
 
 	repo := New(db)
 
-	rows := mock.NewRows([]string{"id", "userID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "reviewed"})
-	rows.AddRow(response.ID, response.UserID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.Reviewed)
+	rows := mock.NewRows([]string{"id", "userID", "vendorName", "createdAt", "clientAddress", "orderStatus", "price", "delivery_fee", "reviewed", "payment_status", "refunded_amount", "deliver_at"})
+	rows.AddRow(response.ID, response.UserID, response.VendorName, response.CreatedAt, response.Address, response.Status, response.Price, response.DeliveryFee, response.Reviewed, response.PaymentStatus, response.RefundedAmount, nil)
 
 	// bad query
 	mock.
@@ -512,7 +642,7 @@ func TestGetUserOrdersProductsError(t *testing.T) {
 		WillReturnRows(rows)
 
 	mock.
-		ExpectQuery("SELECT productName").
+		ExpectQuery("SELECT id, productName").
 		WithArgs(response.ID).
 		WillReturnError(dbError)
 
@@ -770,25 +900,282 @@ func TestUpdateOrderStatus(t *testing.T) {
 
 	repo := New(db)
 
+	change := models.OrderStatusChange{
+		Status:    response.Status,
+		UserID:    userID,
+		Comment:   "test comment",
+		ChangedAt: time.Now(),
+	}
+
 	// good query
+	mock.ExpectBegin()
+
 	mock.
 		ExpectExec("UPDATE").
-		WithArgs(response.Status, strconv.Itoa(response.ID)).
+		WithArgs(change.Status, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(strconv.Itoa(response.ID), change.Status, change.UserID, change.Comment, change.ChangedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), response.Status)
+	mock.ExpectCommit()
+
+	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
 
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
 	// bad query
+	mock.ExpectBegin()
+
 	mock.
 		ExpectExec("UPDATE").
-		WithArgs(response.Status, strconv.Itoa(response.ID)).
+		WithArgs(change.Status, strconv.Itoa(response.ID), models.OrderStatusCreated).
 		WillReturnError(dbError)
 
-	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), response.Status)
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// status changed concurrently
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE").
+		WithArgs(change.Status, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if !errors.Is(err, order.ErrWrongStatusTransition) {
+		t.Errorf("expected wrong status transition. Got: %v", err)
+	}
+
+	// history error
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE").
+		WithArgs(change.Status, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(strconv.Itoa(response.ID), change.Status, change.UserID, change.Comment, change.ChangedAt).
+		WillReturnError(dbError)
+
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// unpaid order isn't accepted
+	accept := change
+	accept.Status = models.OrderStatusAccepted
+
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE orders SET orderStatus = \\$1 WHERE id = \\$2 AND orderStatus = \\$3 AND payment_status = \\$4").
+		WithArgs(accept.Status, strconv.Itoa(response.ID), models.OrderStatusCreated, models.PaymentStatusPaid).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(strconv.Itoa(response.ID), models.OrderStatusCreated, accept)
+
+	if !errors.Is(err, order.ErrWrongStatusTransition) {
+		t.Errorf("expected wrong status transition. Got: %v", err)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	change := models.OrderStatusChange{
+		Status:    models.OrderStatusCancelled,
+		UserID:    userID,
+		Comment:   "changed my mind",
+		ChangedAt: time.Now(),
+	}
+
+	// good query
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE orders SET orderStatus").
+		WithArgs(change.Status, change.Comment, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.
+		ExpectExec("INSERT INTO order_status_history").
+		WithArgs(strconv.Itoa(response.ID), change.Status, change.UserID, change.Comment, change.ChangedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectCommit()
+
+	err = repo.CancelOrder(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// status changed concurrently
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE orders SET orderStatus").
+		WithArgs(change.Status, change.Comment, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectRollback()
+
+	err = repo.CancelOrder(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if !errors.Is(err, order.ErrWrongStatusTransition) {
+		t.Errorf("expected wrong status transition. Got: %v", err)
+	}
+
+	// bad query
+	mock.ExpectBegin()
+
+	mock.
+		ExpectExec("UPDATE orders SET orderStatus").
+		WithArgs(change.Status, change.Comment, strconv.Itoa(response.ID), models.OrderStatusCreated).
+		WillReturnError(dbError)
+
+	mock.ExpectRollback()
+
+	err = repo.CancelOrder(strconv.Itoa(response.ID), models.OrderStatusCreated, change)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	changedAt := time.Now()
+	expected := []models.OrderStatusChange{
+		{
+			Status:       models.OrderStatusCreated,
+			UserID:       userID,
+			ChangedAt:    changedAt,
+			ChangedAtStr: changedAt.Format(configs.TimeFormat),
+		},
+		{
+			Status:       models.OrderStatusAccepted,
+			UserID:       "2",
+			Comment:      "test comment",
+			ChangedAt:    changedAt,
+			ChangedAtStr: changedAt.Format(configs.TimeFormat),
+		},
+	}
+
+	// good query
+	rows := mock.NewRows([]string{"orderStatus", "userID", "comment", "changed_at"})
+	for _, change := range expected {
+		rows.AddRow(change.Status, change.UserID, change.Comment, change.ChangedAt)
+	}
+
+	mock.
+		ExpectQuery("SELECT orderStatus").
+		WithArgs(strconv.Itoa(response.ID)).
+		WillReturnRows(rows)
+
+	history, err := repo.GetOrderHistory(strconv.Itoa(response.ID))
+
+	if !reflect.DeepEqual(expected, history) {
+		t.Errorf("expected: %v\n got: %v", expected, history)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT orderStatus").
+		WithArgs(strconv.Itoa(response.ID)).
+		WillReturnError(dbError)
+
+	_, err = repo.GetOrderHistory(strconv.Itoa(response.ID))
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetOrderStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	rows := mock.NewRows([]string{"orderStatus"}).AddRow(response.Status)
+	mock.
+		ExpectQuery("SELECT orderStatus FROM orders").
+		WithArgs(strconv.Itoa(response.ID)).
+		WillReturnRows(rows)
+
+	status, err := repo.GetOrderStatus(strconv.Itoa(response.ID))
+
+	if status != response.Status {
+		t.Errorf("expected: %v\n got: %v", response.Status, status)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// no rows
+	mock.
+		ExpectQuery("SELECT orderStatus FROM orders").
+		WithArgs(strconv.Itoa(response.ID)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetOrderStatus(strconv.Itoa(response.ID))
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT orderStatus FROM orders").
+		WithArgs(strconv.Itoa(response.ID)).
+		WillReturnError(dbError)
+
+	_, err = repo.GetOrderStatus(strconv.Itoa(response.ID))
 
 	if err == nil {
 		t.Errorf("expected error. Got nil")
@@ -851,3 +1238,182 @@ func TestGetProductsFromOrder(t *testing.T) {
 		t.Errorf("expected error. Got nil")
 	}
 }
+
+func TestSetPaymentIntent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectExec("UPDATE orders SET payment_intent").
+		WithArgs("pi_1", response.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetPaymentIntent(response.ID, "pi_1")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("UPDATE orders SET payment_intent").
+		WithArgs("pi_1", response.ID).
+		WillReturnError(dbError)
+
+	err = repo.SetPaymentIntent(response.ID, "pi_1")
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestUpdatePaymentStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectExec("UPDATE orders SET payment_status = \\$1 WHERE payment_intent = \\$2 AND payment_status = \\$3").
+		WithArgs(models.PaymentStatusPaid, "pi_1", models.PaymentStatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	changed, err := repo.UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusPaid)
+
+	if !changed || err != nil {
+		t.Errorf("expected changed status. Got: %v, %v", changed, err)
+	}
+
+	// payment is already refunded
+	mock.
+		ExpectExec("UPDATE orders SET payment_status").
+		WithArgs(models.PaymentStatusPaid, "pi_1", models.PaymentStatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	changed, err = repo.UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusPaid)
+
+	if changed || err != nil {
+		t.Errorf("expected unchanged status. Got: %v, %v", changed, err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("UPDATE orders SET payment_status").
+		WithArgs(models.PaymentStatusPaid, "pi_1", models.PaymentStatusPending).
+		WillReturnError(dbError)
+
+	_, err = repo.UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusPaid)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestReleaseScheduledOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	before := time.Now()
+	deliverAt := before.Add(-time.Minute)
+	expected := []models.OrderResponse{{
+		ID:           response.ID,
+		VendorID:     response.VendorID,
+		VendorName:   response.VendorName,
+		DeliverAt:    deliverAt,
+		DeliverAtStr: deliverAt.Format(configs.TimeFormat),
+	}}
+
+	// good query
+	mock.
+		ExpectQuery("UPDATE orders SET released = true WHERE NOT released AND deliver_at <= \\$1 AND orderStatus = \\$2").
+		WithArgs(before, models.OrderStatusCreated).
+		WillReturnRows(mock.NewRows([]string{"id", "vendorID", "vendorName", "deliver_at"}).
+			AddRow(response.ID, response.VendorID, response.VendorName, deliverAt))
+
+	orders, err := repo.ReleaseScheduledOrders(before)
+
+	if !reflect.DeepEqual(expected, orders) {
+		t.Errorf("expected: %v\n got: %v", expected, orders)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// only a cancelled order is due
+	mock.
+		ExpectQuery("UPDATE orders SET released = true").
+		WithArgs(before, models.OrderStatusCreated).
+		WillReturnRows(mock.NewRows([]string{"id", "vendorID", "vendorName", "deliver_at"}))
+
+	orders, err = repo.ReleaseScheduledOrders(before)
+
+	if len(orders) != 0 || err != nil {
+		t.Errorf("expected no released orders. Got: %v, %v", orders, err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("UPDATE orders SET released = true").
+		WithArgs(before, models.OrderStatusCreated).
+		WillReturnError(dbError)
+
+	_, err = repo.ReleaseScheduledOrders(before)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetUnrefundedOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectQuery("SELECT id FROM orders").
+		WithArgs(models.OrderStatusCancelled, models.OrderStatusRejected, models.PaymentStatusPaid).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+
+	ids, err := repo.GetUnrefundedOrders()
+
+	if !reflect.DeepEqual([]string{"1", "2"}, ids) {
+		t.Errorf("expected: %v\n got: %v", []string{"1", "2"}, ids)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT id FROM orders").
+		WithArgs(models.OrderStatusCancelled, models.OrderStatusRejected, models.PaymentStatusPaid).
+		WillReturnError(dbError)
+
+	_, err = repo.GetUnrefundedOrders()
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
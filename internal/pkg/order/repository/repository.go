@@ -3,11 +3,13 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/order"
 	ownErr "github.com/friends/pkg/error"
+	"github.com/lib/pq"
 )
 
 type OrderRepository struct {
@@ -25,35 +27,92 @@ func (o OrderRepository) AddOrder(userID string, order models.OrderRequest) (int
 	if err != nil {
 		return 0, fmt.Errorf("couldn't create transaction: %w", err)
 	}
-	var orderID int
 
-	err = tx.QueryRow(
-		`INSERT INTO orders (userID, vendorID, vendorName, createdAt, clientAddress, price)
-		VALUES($1, $2, $3, $4, $5, $6) RETURNING id`,
+	orderID, err := insertOrder(tx, userID, order)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return orderID, nil
+}
+
+// AddOrderFromCart creates the order and removes its products from
+// the user's cart in one transaction.
+func (o OrderRepository) AddOrderFromCart(userID string, order models.OrderRequest) (int, error) {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	orderID, err := insertOrder(tx, userID, order)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	productIDs := make([]int, 0, len(order.Items))
+	for _, item := range order.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM carts WHERE userID = $1 AND productID = ANY ($2)",
+		userID, pq.Array(productIDs),
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("couldn't clear cart: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return orderID, nil
+}
+
+func insertOrder(tx *sql.Tx, userID string, order models.OrderRequest) (int, error) {
+	var orderID int
+	err := tx.QueryRow(
+		`INSERT INTO orders (userID, vendorID, vendorName, createdAt, clientAddress, price, delivery_distance, delivery_fee,
+		deliver_at, released) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		userID, order.VendorID, order.VendorName, order.CreatedAt, order.Address, order.Price,
+		order.Distance, order.DeliveryFee, sql.NullTime{Time: order.DeliverAt, Valid: !order.DeliverAt.IsZero()},
+		order.DeliverAt.IsZero(),
 	).Scan(&orderID)
 
 	if err != nil {
-		_ = tx.Rollback()
 		return 0, fmt.Errorf("couldn't insert order: %w", err)
 	}
 
 	for _, product := range order.Products {
 		_, err = tx.Exec(
-			"INSERT INTO products_in_order (orderID, productName, price, picture) VALUES($1, $2, $3, $4)",
-			orderID, product.Name, product.Price, product.Picture,
+			"INSERT INTO products_in_order (orderID, productName, price, picture, quantity) VALUES($1, $2, $3, $4, $5)",
+			orderID, product.Name, product.Price, product.Picture, product.Quantity,
 		)
 
 		if err != nil {
-			_ = tx.Rollback()
 			return 0, fmt.Errorf("couldn't insert product: %w", err)
 		}
 	}
 
-	err = tx.Commit()
+	_, err = tx.Exec(
+		"INSERT INTO order_status_history (orderID, orderStatus, userID, changed_at) VALUES($1, $2, $3, $4)",
+		orderID, models.OrderStatusCreated, userID, order.CreatedAt,
+	)
+
 	if err != nil {
-		_ = tx.Rollback()
-		return 0, fmt.Errorf("couldn't commit transaction: %w", err)
+		return 0, fmt.Errorf("couldn't insert order status history: %w", err)
 	}
 
 	return orderID, nil
@@ -61,15 +120,20 @@ func (o OrderRepository) AddOrder(userID string, order models.OrderRequest) (int
 
 func (o OrderRepository) GetOrder(orderID string) (models.OrderResponse, error) {
 	var order models.OrderResponse
+	var deliverAt sql.NullTime
 	err := o.db.QueryRow(
-		`SELECT id, userID, vendorID, vendorName, createdAt, clientAddress, orderStatus, price, reviewed
+		`SELECT id, userID, vendorID, vendorName, createdAt, clientAddress, orderStatus, price, delivery_fee, reviewed,
+		cancel_reason, payment_status, payment_intent,
+		(SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE orderID = orders.id), deliver_at
 		FROM orders WHERE id = $1`,
 		orderID,
 	).Scan(
 		&order.ID, &order.UserID, &order.VendorID, &order.VendorName, &order.CreatedAt,
-		&order.Address, &order.Status, &order.Price, &order.Reviewed,
+		&order.Address, &order.Status, &order.Price, &order.DeliveryFee, &order.Reviewed, &order.CancelReason,
+		&order.PaymentStatus, &order.PaymentIntent, &order.RefundedAmount, &deliverAt,
 	)
 	order.CreatedAtStr = order.CreatedAt.Format(configs.TimeFormat)
+	setDeliverAt(&order, deliverAt)
 
 	if err != nil {
 		return models.OrderResponse{}, ownErr.NewServerError(fmt.Errorf("couldn't get order from db: %w", err))
@@ -85,8 +149,8 @@ func (o OrderRepository) GetOrder(orderID string) (models.OrderResponse, error)
 
 func (o OrderRepository) GetUserOrders(userID string) ([]models.OrderResponse, error) {
 	rows, err := o.db.Query(
-		`SELECT id, userID, vendorName, createdAt, clientAddress, orderStatus, price, reviewed
-		FROM orders WHERE userID = $1`,
+		`SELECT id, userID, vendorName, createdAt, clientAddress, orderStatus, price, delivery_fee, reviewed, payment_status,
+		(SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE orderID = orders.id), deliver_at FROM orders WHERE userID = $1`,
 		userID,
 	)
 
@@ -98,14 +162,17 @@ func (o OrderRepository) GetUserOrders(userID string) ([]models.OrderResponse, e
 	orders := make([]models.OrderResponse, 0)
 	for rows.Next() {
 		var order models.OrderResponse
+		var deliverAt sql.NullTime
 		err = rows.Scan(
 			&order.ID, &order.UserID, &order.VendorName, &order.CreatedAt,
-			&order.Address, &order.Status, &order.Price, &order.Reviewed,
+			&order.Address, &order.Status, &order.Price, &order.DeliveryFee, &order.Reviewed, &order.PaymentStatus,
+			&order.RefundedAmount, &deliverAt,
 		)
 		if err != nil {
 			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get order from db: %w", err))
 		}
 		order.CreatedAtStr = order.CreatedAt.Format(configs.TimeFormat)
+		setDeliverAt(&order, deliverAt)
 
 		err = o.GetProductsFromOrder(&order)
 		if err != nil {
@@ -134,8 +201,9 @@ func (o OrderRepository) CheckOrderByUser(userID string, orderID string) bool {
 
 func (o OrderRepository) GetVendorOrders(vendorID string) ([]models.OrderResponse, error) {
 	rows, err := o.db.Query(
-		`SELECT id, userID, createdAt, clientAddress, orderStatus, price, reviewed
-		FROM orders WHERE vendorID = $1`,
+		`SELECT id, userID, createdAt, clientAddress, orderStatus, price, delivery_fee, reviewed, payment_status,
+		(SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE orderID = orders.id), deliver_at
+		FROM orders WHERE vendorID = $1 AND released`,
 		vendorID,
 	)
 
@@ -147,14 +215,17 @@ func (o OrderRepository) GetVendorOrders(vendorID string) ([]models.OrderRespons
 	orders := make([]models.OrderResponse, 0)
 	for rows.Next() {
 		var order models.OrderResponse
+		var deliverAt sql.NullTime
 		err = rows.Scan(
 			&order.ID, &order.UserID, &order.CreatedAt,
-			&order.Address, &order.Status, &order.Price, &order.Reviewed,
+			&order.Address, &order.Status, &order.Price, &order.DeliveryFee, &order.Reviewed, &order.PaymentStatus,
+			&order.RefundedAmount, &deliverAt,
 		)
 		if err != nil {
 			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get order from db: %w", err))
 		}
 		order.CreatedAtStr = order.CreatedAt.Format(configs.TimeFormat)
+		setDeliverAt(&order, deliverAt)
 
 		err = o.GetProductsFromOrder(&order)
 		if err != nil {
@@ -192,22 +263,170 @@ func (o OrderRepository) GetVendorOrdersIDs(vendorID string) ([]int, error) {
 	return ids, nil
 }
 
-func (o OrderRepository) UpdateOrderStatus(orderID string, status string) error {
+// UpdateOrderStatus changes the status only if it's still the given one.
+// An order is accepted only once it's paid.
+func (o OrderRepository) UpdateOrderStatus(orderID string, from string, change models.OrderStatusChange) error {
+	query := "UPDATE orders SET orderStatus = $1 WHERE id = $2 AND orderStatus = $3"
+	args := []interface{}{change.Status, orderID, from}
+	if change.Status == models.OrderStatusAccepted {
+		query += " AND payment_status = $4"
+		args = append(args, models.PaymentStatusPaid)
+	}
+
+	return o.changeStatus(orderID, from, change, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.Exec(query, args...)
+	})
+}
+
+func (o OrderRepository) CancelOrder(orderID string, from string, change models.OrderStatusChange) error {
+	return o.changeStatus(orderID, from, change, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.Exec(
+			"UPDATE orders SET orderStatus = $1, cancel_reason = $2 WHERE id = $3 AND orderStatus = $4",
+			change.Status, change.Comment, orderID, from,
+		)
+	})
+}
+
+// changeStatus runs the status update and records the change in the order
+// history in one transaction. The update must match only an order that's
+// still in the from status.
+func (o OrderRepository) changeStatus(
+	orderID string, from string, change models.OrderStatusChange, update func(tx *sql.Tx) (sql.Result, error),
+) error {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	result, err := update(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't update status on orderID: %w", err)
+	}
+
+	err = checkStatusChanged(result, from)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO order_status_history (orderID, orderStatus, userID, comment, changed_at)
+		VALUES($1, $2, $3, $4, $5)`,
+		orderID, change.Status, change.UserID, change.Comment, change.ChangedAt,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't insert order status history: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func checkStatusChanged(result sql.Result, from string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("couldn't get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: order is no longer in status %q or isn't paid", order.ErrWrongStatusTransition, from)
+	}
+
+	return nil
+}
+
+func (o OrderRepository) GetOrderHistory(orderID string) ([]models.OrderStatusChange, error) {
+	rows, err := o.db.Query(
+		`SELECT orderStatus, COALESCE(userID::TEXT, ''), comment, changed_at FROM order_status_history
+		WHERE orderID = $1 ORDER BY changed_at, id`,
+		orderID,
+	)
+
+	if err != nil {
+		return nil, ownErr.NewServerError(fmt.Errorf("couldn't get order history from db: %w", err))
+	}
+	defer rows.Close()
+
+	history := make([]models.OrderStatusChange, 0)
+	for rows.Next() {
+		var change models.OrderStatusChange
+		err = rows.Scan(&change.Status, &change.UserID, &change.Comment, &change.ChangedAt)
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get order status change from db: %w", err))
+		}
+		change.ChangedAtStr = change.ChangedAt.Format(configs.TimeFormat)
+
+		history = append(history, change)
+	}
+
+	return history, nil
+}
+
+func (o OrderRepository) GetOrderStatus(orderID string) (string, error) {
+	var status string
+	err := o.db.QueryRow(
+		"SELECT orderStatus FROM orders WHERE id = $1",
+		orderID,
+	).Scan(&status)
+
+	if err == sql.ErrNoRows {
+		return "", ownErr.NewClientError(fmt.Errorf("no such order"))
+	}
+
+	if err != nil {
+		return "", ownErr.NewServerError(fmt.Errorf("couldn't get order status from db: %w", err))
+	}
+
+	return status, nil
+}
+
+func (o OrderRepository) SetPaymentIntent(orderID int, intentID string) error {
 	_, err := o.db.Exec(
-		"UPDATE orders SET orderStatus = $1 WHERE id = $2",
-		status, orderID,
+		"UPDATE orders SET payment_intent = $1 WHERE id = $2",
+		intentID, orderID,
 	)
 
 	if err != nil {
-		return fmt.Errorf("couldn't update status on orderID: %w", err)
+		return fmt.Errorf("couldn't set payment intent on order: %w", err)
 	}
 
 	return nil
 }
 
+// UpdatePaymentStatus changes the payment status only if it's still the
+// given one and tells whether it was changed, so a late or repeated
+// webhook can't overwrite a final status.
+func (o OrderRepository) UpdatePaymentStatus(intentID string, from string, status string) (bool, error) {
+	result, err := o.db.Exec(
+		"UPDATE orders SET payment_status = $1 WHERE payment_intent = $2 AND payment_status = $3",
+		status, intentID, from,
+	)
+
+	if err != nil {
+		return false, ownErr.NewServerError(fmt.Errorf("couldn't update payment status: %w", err))
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, ownErr.NewServerError(fmt.Errorf("couldn't get updated orders count: %w", err))
+	}
+
+	return affected != 0, nil
+}
+
 func (o OrderRepository) GetProductsFromOrder(order *models.OrderResponse) error {
 	rows, err := o.db.Query(
-		"SELECT productName, price, picture FROM products_in_order WHERE orderID = $1",
+		`SELECT id, productName, price, picture, quantity,
+		(SELECT COALESCE(SUM(quantity), 0) FROM refunds WHERE itemID = products_in_order.id)
+		FROM products_in_order WHERE orderID = $1 ORDER BY id`,
 		order.ID,
 	)
 
@@ -218,7 +437,7 @@ func (o OrderRepository) GetProductsFromOrder(order *models.OrderResponse) error
 
 	for rows.Next() {
 		product := models.OrderProduct{}
-		err = rows.Scan(&product.Name, &product.Price, &product.Picture)
+		err = rows.Scan(&product.ID, &product.Name, &product.Price, &product.Picture, &product.Quantity, &product.Refunded)
 		if err != nil {
 			return fmt.Errorf("couldn't get product: %w", err)
 		}
@@ -277,3 +496,70 @@ func (o OrderRepository) GetUserIDFromOrder(orderID int) (string, error) {
 
 	return userID, nil
 }
+
+// ReleaseScheduledOrders moves the scheduled orders with a delivery slot
+// before the given moment into the active queue of their vendors.
+// Cancelled orders are never released.
+func (o OrderRepository) ReleaseScheduledOrders(before time.Time) ([]models.OrderResponse, error) {
+	rows, err := o.db.Query(
+		`UPDATE orders SET released = true WHERE NOT released AND deliver_at <= $1 AND orderStatus = $2
+		RETURNING id, vendorID, vendorName, deliver_at`,
+		before, models.OrderStatusCreated,
+	)
+
+	if err != nil {
+		return nil, ownErr.NewServerError(fmt.Errorf("couldn't release scheduled orders: %w", err))
+	}
+	defer rows.Close()
+
+	orders := make([]models.OrderResponse, 0)
+	for rows.Next() {
+		var order models.OrderResponse
+		var deliverAt sql.NullTime
+		err = rows.Scan(&order.ID, &order.VendorID, &order.VendorName, &deliverAt)
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get released order: %w", err))
+		}
+		setDeliverAt(&order, deliverAt)
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// GetUnrefundedOrders returns ids of cancelled and rejected orders
+// whose payment wasn't returned yet.
+func (o OrderRepository) GetUnrefundedOrders() ([]string, error) {
+	rows, err := o.db.Query(
+		"SELECT id FROM orders WHERE orderStatus IN ($1, $2) AND payment_status = $3 ORDER BY id",
+		models.OrderStatusCancelled, models.OrderStatusRejected, models.PaymentStatusPaid,
+	)
+
+	if err != nil {
+		return nil, ownErr.NewServerError(fmt.Errorf("couldn't get unrefunded orders: %w", err))
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get unrefunded order: %w", err))
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func setDeliverAt(order *models.OrderResponse, deliverAt sql.NullTime) {
+	if !deliverAt.Valid {
+		return
+	}
+
+	order.DeliverAt = deliverAt.Time
+	order.DeliverAtStr = deliverAt.Time.Format(configs.TimeFormat)
+}
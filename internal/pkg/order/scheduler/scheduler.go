@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/friends/internal/pkg/order"
+	log "github.com/friends/pkg/logger"
+)
+
+// Scheduler periodically releases scheduled orders into the vendor queue
+// and notifies the partners about them. It also retries the refunds
+// of cancelled orders that failed when the order was cancelled.
+type Scheduler struct {
+	orderUsecase order.Usecase
+	interval     time.Duration
+	lead         time.Duration
+}
+
+func New(orderUsecase order.Usecase, interval, lead time.Duration) Scheduler {
+	return Scheduler{
+		orderUsecase: orderUsecase,
+		interval:     interval,
+		lead:         lead,
+	}
+}
+
+func (s Scheduler) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Release()
+			s.RetryRefunds()
+		}
+	}
+}
+
+// Release moves due orders into the vendor queue and notifies partners.
+func (s Scheduler) Release() {
+	orders, err := s.orderUsecase.ReleaseScheduledOrders(s.lead)
+	if err != nil {
+		log.ErrorMessage(err.Error())
+		return
+	}
+
+	for _, released := range orders {
+		err = s.orderUsecase.NotifyNewOrder(released.ID)
+		if err != nil {
+			log.ErrorMessage(fmt.Sprintf("couldn't notify about order %v: %v", released.ID, err))
+		}
+	}
+}
+
+// RetryRefunds returns the money of cancelled and rejected orders
+// that are still paid.
+func (s Scheduler) RetryRefunds() {
+	orderIDs, err := s.orderUsecase.GetUnrefundedOrders()
+	if err != nil {
+		log.ErrorMessage(err.Error())
+		return
+	}
+
+	for _, orderID := range orderIDs {
+		err = s.orderUsecase.RetryRefund(orderID)
+		if err != nil {
+			log.ErrorMessage(fmt.Sprintf("couldn't refund order %v: %v", orderID, err))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/order"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	scheduler := New(mockOrderUsecase, time.Minute, time.Hour)
+
+	released := []models.OrderResponse{{ID: 1}, {ID: 2}}
+
+	// every released order is announced
+	mockOrderUsecase.EXPECT().ReleaseScheduledOrders(time.Hour).Times(1).Return(released, nil)
+	mockOrderUsecase.EXPECT().NotifyNewOrder(1).Times(1).Return(fmt.Errorf("partner error"))
+	mockOrderUsecase.EXPECT().NotifyNewOrder(2).Times(1).Return(nil)
+
+	scheduler.Release()
+
+	// db error
+	mockOrderUsecase.EXPECT().ReleaseScheduledOrders(time.Hour).Times(1).Return(nil, fmt.Errorf("db error"))
+
+	scheduler.Release()
+}
+
+func TestRetryRefunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := order.NewMockUsecase(ctrl)
+	scheduler := New(mockOrderUsecase, time.Minute, time.Hour)
+
+	// a failed refund doesn't stop the others
+	mockOrderUsecase.EXPECT().GetUnrefundedOrders().Times(1).Return([]string{"1", "2"}, nil)
+	mockOrderUsecase.EXPECT().RetryRefund("1").Times(1).Return(fmt.Errorf("provider error"))
+	mockOrderUsecase.EXPECT().RetryRefund("2").Times(1).Return(nil)
+
+	scheduler.RetryRefunds()
+
+	// db error
+	mockOrderUsecase.EXPECT().GetUnrefundedOrders().Times(1).Return(nil, fmt.Errorf("db error"))
+
+	scheduler.RetryRefunds()
+}
@@ -0,0 +1,54 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+const (
+	ActorCustomer = "customer"
+	ActorPartner  = "partner"
+	ActorSystem   = "system"
+)
+
+var (
+	ErrWrongStatusTransition = fmt.Errorf("wrong order status transition")
+	ErrWrongDeliveryTime     = fmt.Errorf("wrong delivery time")
+	ErrPaymentFailed         = fmt.Errorf("payment failed")
+)
+
+// statusTransitions maps the current status to the statuses it can move to
+// and the actors allowed to make each step.
+var statusTransitions = map[string]map[string][]string{
+	models.OrderStatusCreated: {
+		models.OrderStatusAccepted:  {ActorPartner},
+		models.OrderStatusRejected:  {ActorPartner},
+		models.OrderStatusCancelled: {ActorCustomer, ActorSystem},
+	},
+	models.OrderStatusAccepted: {
+		models.OrderStatusCooking:   {ActorPartner},
+		models.OrderStatusCancelled: {ActorPartner, ActorSystem},
+	},
+	models.OrderStatusCooking: {
+		models.OrderStatusDelivering: {ActorPartner},
+	},
+	models.OrderStatusDelivering: {
+		models.OrderStatusDelivered: {ActorPartner, ActorSystem},
+	},
+}
+
+func CanChangeStatus(from, to, actor string) bool {
+	actors, ok := statusTransitions[from][to]
+	if !ok {
+		return false
+	}
+
+	for _, allowed := range actors {
+		if allowed == actor {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,14 +1,26 @@
 package order
 
-import "github.com/friends/internal/pkg/models"
+import (
+	"time"
+
+	"github.com/friends/internal/pkg/models"
+)
 
 //go:generate mockgen -destination=./usecase_mock.go -package=order github.com/friends/internal/pkg/order Usecase
 type Usecase interface {
 	AddOrder(userID string, order models.OrderRequest) (int, error)
+	Checkout(userID string, order models.OrderRequest) (int, error)
 	GetOrder(userID string, orderID string) (models.OrderResponse, error)
 	GetUserOrders(userID string) ([]models.OrderResponse, error)
 	GetVendorOrders(vendorID string) (models.VendorOrdersResponse, error)
-	UpdateOrderStatus(orderID string, status string) error
+	UpdateOrderStatus(vendorID string, orderID string, change models.OrderStatusChange, actor string) error
+	GetOrderHistory(userID string, orderID string) ([]models.OrderStatusChange, error)
+	CancelOrder(userID string, orderID string, reason string) error
+	HandlePaymentWebhook(payload []byte, signature string) error
 	GetVendorIDFromOrder(orderID int) (int, error)
 	GetUserIDFromOrder(orderID int) (string, error)
+	ReleaseScheduledOrders(lead time.Duration) ([]models.OrderResponse, error)
+	NotifyNewOrder(orderID int) error
+	GetUnrefundedOrders() ([]string, error)
+	RetryRefund(orderID string) error
 }
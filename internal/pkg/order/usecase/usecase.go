@@ -1,51 +1,368 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/cart"
+	"github.com/friends/internal/pkg/events"
+	"github.com/friends/internal/pkg/geocoder"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/order"
+	"github.com/friends/internal/pkg/payment"
+	"github.com/friends/internal/pkg/refund"
 	"github.com/friends/internal/pkg/vendors"
 	ownErr "github.com/friends/pkg/error"
+	log "github.com/friends/pkg/logger"
 )
 
 type OrderUsecase struct {
 	orderRepository  order.Repository
 	vendorRepository vendors.Repository
+	cartRepository   cart.Repository
+	paymentProvider  payment.Provider
+	refundUsecase    refund.Usecase
+	geocoder         geocoder.Geocoder
+	eventsUsecase    events.Usecase
 }
 
-func New(orderRepository order.Repository, vendorRepository vendors.Repository) order.Usecase {
+func New(
+	orderRepository order.Repository, vendorRepository vendors.Repository, cartRepository cart.Repository,
+	paymentProvider payment.Provider, refundUsecase refund.Usecase, geocoder geocoder.Geocoder,
+	eventsUsecase events.Usecase,
+) order.Usecase {
 	return OrderUsecase{
 		orderRepository:  orderRepository,
 		vendorRepository: vendorRepository,
+		cartRepository:   cartRepository,
+		paymentProvider:  paymentProvider,
+		refundUsecase:    refundUsecase,
+		geocoder:         geocoder,
+		eventsUsecase:    eventsUsecase,
 	}
 }
 
 func (o OrderUsecase) AddOrder(userID string, order models.OrderRequest) (int, error) {
-	vendor, err := o.vendorRepository.GetVendorFromProduct(order.ProductIDs[0])
+	err := o.fillOrder(&order)
 	if err != nil {
-		return 0, fmt.Errorf("error with db: %w", err)
+		return 0, err
+	}
+
+	orderID, err := o.orderRepository.AddOrder(userID, order)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = o.pay(userID, orderID, order.Price+order.DeliveryFee)
+	if err != nil {
+		return 0, err
+	}
+
+	return orderID, nil
+}
+
+// Checkout builds the order from the user's persisted cart with current
+// product prices instead of trusting the product list sent by the client.
+func (o OrderUsecase) Checkout(userID string, order models.OrderRequest) (int, error) {
+	cartProducts, err := o.cartRepository.GetProducts(userID)
+	if err != nil {
+		return 0, ownErr.NewServerError(err)
+	}
+
+	if len(cartProducts) == 0 {
+		return 0, ownErr.NewClientError(cart.ErrCartIsEmpty)
+	}
+
+	order.ProductIDs = nil
+	order.Items = cartProducts
+
+	err = o.fillOrder(&order)
+	if err != nil {
+		return 0, err
+	}
+
+	orderID, err := o.orderRepository.AddOrderFromCart(userID, order)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled, err := o.pay(userID, orderID, order.Price+order.DeliveryFee)
+	if cancelled {
+		// The cart was cleared together with the order creation,
+		// give it back so the user can retry the payment.
+		restoreErr := o.restoreCart(userID, order.VendorID, cartProducts)
+		if restoreErr != nil {
+			return 0, ownErr.NewServerError(restoreErr)
+		}
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return orderID, nil
+}
+
+// pay charges the order and tells whether the order was cancelled. A declined
+// order is cancelled, so it never reaches the partner and a retry creates
+// a new one. So is an order whose intent couldn't be started, nothing was
+// charged then. An error after the capture leaves the order pending, the
+// provider webhook reports the result.
+func (o OrderUsecase) pay(userID string, orderID int, amount int) (cancelled bool, err error) {
+	intent, err := o.paymentProvider.CreateIntent(orderID, amount)
+	if err == nil {
+		err = o.orderRepository.SetPaymentIntent(orderID, intent.ID)
+	}
+
+	if err != nil {
+		cancelErr := o.cancelUnpaid(userID, orderID)
+		if cancelErr != nil {
+			return false, cancelErr
+		}
+
+		return true, ownErr.NewServerError(fmt.Errorf("couldn't start payment: %w", err))
+	}
+
+	intent, err = o.paymentProvider.Capture(intent.ID)
+	if err != nil {
+		return false, ownErr.NewServerError(fmt.Errorf("couldn't capture payment: %w", err))
+	}
+
+	if intent.Status == models.PaymentStatusPending {
+		return false, nil
+	}
+
+	_, err = o.orderRepository.UpdatePaymentStatus(intent.ID, models.PaymentStatusPending, intent.Status)
+	if err != nil {
+		return false, ownErr.NewServerError(fmt.Errorf("couldn't save payment status: %w", err))
+	}
+
+	if intent.Status != models.PaymentStatusFailed {
+		return false, nil
+	}
+
+	err = o.cancelUnpaid(userID, orderID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, ownErr.NewClientError(fmt.Errorf("%w: payment %v was declined", order.ErrPaymentFailed, intent.ID))
+}
+
+// cancelUnpaid cancels the order whose payment failed. An order the customer
+// has already cancelled is left as is.
+func (o OrderUsecase) cancelUnpaid(userID string, orderID int) error {
+	change := models.OrderStatusChange{
+		Status:    models.OrderStatusCancelled,
+		UserID:    userID,
+		Comment:   configs.PaymentFailedReason,
+		ChangedAt: time.Now(),
+	}
+
+	err := o.orderRepository.CancelOrder(strconv.Itoa(orderID), models.OrderStatusCreated, change)
+	if errors.Is(err, order.ErrWrongStatusTransition) {
+		return nil
+	}
+
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't cancel unpaid order %v: %w", orderID, err))
+	}
+
+	return nil
+}
+
+func (o OrderUsecase) restoreCart(userID string, vendorID int, products []models.ProductQuantity) error {
+	for _, product := range products {
+		err := o.cartRepository.Add(userID, strconv.Itoa(product.ProductID), strconv.Itoa(vendorID), product.Quantity)
+		if err != nil {
+			return fmt.Errorf("couldn't restore cart: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// HandlePaymentWebhook saves the result of a pending payment. Events for
+// payments that already have a result are ignored. An order whose payment
+// failed is cancelled the same way as when the capture is declined.
+func (o OrderUsecase) HandlePaymentWebhook(payload []byte, signature string) error {
+	event, err := o.paymentProvider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return ownErr.NewClientError(err)
+	}
+
+	changed, err := o.orderRepository.UpdatePaymentStatus(event.IntentID, models.PaymentStatusPending, event.Status)
+	if err != nil {
+		return err
+	}
+
+	if !changed || event.Status != models.PaymentStatusFailed {
+		return nil
+	}
+
+	userID, err := o.orderRepository.GetUserIDFromOrder(event.OrderID)
+	if err != nil {
+		return err
+	}
+
+	return o.cancelUnpaid(userID, event.OrderID)
+}
+
+// fillOrder sets vendor, products and total price of the order
+// from the current state of the products table.
+func (o OrderUsecase) fillOrder(order *models.OrderRequest) error {
+	ids, quantities, err := productQuantities(*order)
+	if err != nil {
+		return err
+	}
+
+	vendor, err := o.vendorRepository.GetVendorFromProduct(ids[0])
+	if err != nil {
+		return fmt.Errorf("error with db: %w", err)
+	}
+
+	err = o.checkDeliveryTime(vendor.ID, order.DeliverAt)
+	if err != nil {
+		return err
 	}
 
 	order.VendorID = vendor.ID
 	order.VendorName = vendor.Name
 
-	products, err := o.vendorRepository.GetAllProductsWithIDsFromSameVendor(order.ProductIDs)
+	products, err := o.vendorRepository.GetAllProductsWithIDsFromSameVendor(ids)
 	if err != nil {
-		return 0, err
+		return err
+	}
+
+	if len(products) != len(ids) {
+		return ownErr.NewClientError(fmt.Errorf("some products don't exist"))
 	}
 
+	order.Products = nil
+	order.Price = 0
 	for _, product := range products {
+		if product.VendorID != vendor.ID {
+			return ownErr.NewClientError(fmt.Errorf("products from different vendors"))
+		}
+
+		quantity := quantities[product.ID]
 		order.Products = append(order.Products, models.OrderProduct{
-			Name:    product.Name,
-			Price:   product.Price,
-			Picture: product.Picture,
+			Name:     product.Name,
+			Price:    product.Price,
+			Picture:  product.Picture,
+			Quantity: quantity,
 		})
 
-		order.Price += product.Price
+		order.Price += product.Price * quantity
+	}
+
+	return o.applyDeliveryTerms(order)
+}
+
+// checkDeliveryTime checks that the vendor is open now or, for scheduled
+// orders, that the slot is within the allowed window and the vendor is
+// open at that time.
+func (o OrderUsecase) checkDeliveryTime(vendorID int, deliverAt time.Time) error {
+	now := time.Now()
+	at := now
+	if !deliverAt.IsZero() {
+		if deliverAt.Before(now.Add(configs.ScheduleLeadTime)) || deliverAt.After(now.Add(configs.ScheduleHorizon)) {
+			return ownErr.NewClientError(fmt.Errorf("%w: %v", order.ErrWrongDeliveryTime, deliverAt))
+		}
+		at = deliverAt
+	}
+
+	open, err := vendors.IsVendorOpen(o.vendorRepository, vendorID, at)
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	if !open {
+		return ownErr.NewClientError(vendors.ErrVendorClosed)
+	}
+
+	return nil
+}
+
+// applyDeliveryTerms checks the vendor minimum order amount and service area
+// and sets the delivery fee for the distance between the vendor and the address.
+func (o OrderUsecase) applyDeliveryTerms(order *models.OrderRequest) error {
+	settings, err := o.vendorRepository.GetDeliverySettings(order.VendorID)
+	if err != nil {
+		return err
+	}
+
+	if order.Price < settings.MinOrderAmount {
+		return ownErr.NewClientError(
+			fmt.Errorf("%w: %v < %v", vendors.ErrBelowMinOrder, order.Price, settings.MinOrderAmount),
+		)
+	}
+
+	if order.Longitude == 0 && order.Latitude == 0 {
+		order.Longitude, order.Latitude, err = o.geocoder.Geocode(order.Address)
+		if errors.Is(err, geocoder.ErrAddressNotFound) {
+			return ownErr.NewClientError(fmt.Errorf("send coordinates with the address: %w", err))
+		}
+
+		if err != nil {
+			return ownErr.NewServerError(fmt.Errorf("couldn't geocode address: %w", err))
+		}
 	}
 
-	return o.orderRepository.AddOrder(userID, order)
+	inArea, err := o.vendorRepository.IsInServiceArea(order.VendorID, order.Longitude, order.Latitude)
+	if err != nil {
+		return err
+	}
+
+	if !inArea {
+		return ownErr.NewClientError(vendors.ErrOutsideArea)
+	}
+
+	order.Distance, err = o.vendorRepository.GetDistance(order.VendorID, order.Longitude, order.Latitude)
+	if err != nil {
+		return err
+	}
+
+	order.DeliveryFee, err = vendors.DeliveryFee(settings.FeeTiers, order.Distance)
+	if err != nil {
+		return ownErr.NewClientError(err)
+	}
+
+	return nil
+}
+
+// productQuantities merges the flat product list and the items of the order
+// into unique product ids with their total quantities.
+func productQuantities(order models.OrderRequest) ([]int, map[int]int, error) {
+	ids := make([]int, 0, len(order.ProductIDs)+len(order.Items))
+	quantities := make(map[int]int)
+
+	add := func(productID, quantity int) {
+		if _, ok := quantities[productID]; !ok {
+			ids = append(ids, productID)
+		}
+		quantities[productID] += quantity
+	}
+
+	for _, productID := range order.ProductIDs {
+		add(productID, 1)
+	}
+
+	for _, item := range order.Items {
+		if item.Quantity <= 0 {
+			return nil, nil, ownErr.NewClientError(fmt.Errorf("wrong quantity %v for product %v", item.Quantity, item.ProductID))
+		}
+		add(item.ProductID, item.Quantity)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil, ownErr.NewClientError(fmt.Errorf("order has no products"))
+	}
+
+	return ids, quantities, nil
 }
 
 func (o OrderUsecase) GetOrder(userID string, orderID string) (models.OrderResponse, error) {
@@ -58,6 +375,16 @@ func (o OrderUsecase) GetOrder(userID string, orderID string) (models.OrderRespo
 		return models.OrderResponse{}, err
 	}
 
+	order.History, err = o.orderRepository.GetOrderHistory(orderID)
+	if err != nil {
+		return models.OrderResponse{}, err
+	}
+
+	order.Refunds, err = o.refundUsecase.GetOrderRefunds(order.ID)
+	if err != nil {
+		return models.OrderResponse{}, err
+	}
+
 	return order, nil
 }
 
@@ -89,8 +416,144 @@ func (o OrderUsecase) GetVendorOrders(vendorID string) (models.VendorOrdersRespo
 	return vendorWithOrders, nil
 }
 
-func (o OrderUsecase) UpdateOrderStatus(orderID string, status string) error {
-	return o.orderRepository.UpdateOrderStatus(orderID, status)
+func (o OrderUsecase) UpdateOrderStatus(
+	vendorID string, orderID string, change models.OrderStatusChange, actor string,
+) error {
+	orderIDInt, err := strconv.Atoi(orderID)
+	if err != nil {
+		return ownErr.NewClientError(fmt.Errorf("wrong order id: %w", err))
+	}
+
+	orderVendorID, err := o.orderRepository.GetVendorIDFromOrder(orderIDInt)
+	if err != nil {
+		return err
+	}
+
+	if strconv.Itoa(orderVendorID) != vendorID {
+		return ownErr.NewClientError(fmt.Errorf("order doesn't belong to the vendor"))
+	}
+
+	currentStatus, err := o.orderRepository.GetOrderStatus(orderID)
+	if err != nil {
+		return err
+	}
+
+	if !order.CanChangeStatus(currentStatus, change.Status, actor) {
+		return ownErr.NewClientError(
+			fmt.Errorf(
+				"%w: %v can't change status from %q to %q", order.ErrWrongStatusTransition, actor, currentStatus, change.Status,
+			),
+		)
+	}
+
+	err = o.orderRepository.UpdateOrderStatus(orderID, currentStatus, change)
+	if errors.Is(err, order.ErrWrongStatusTransition) {
+		return ownErr.NewClientError(err)
+	}
+
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	if change.Status == models.OrderStatusRejected || change.Status == models.OrderStatusCancelled {
+		o.refund(orderID, change.UserID, change.Comment)
+	}
+
+	return nil
+}
+
+func (o OrderUsecase) CancelOrder(userID string, orderID string, reason string) error {
+	if !o.orderRepository.CheckOrderByUser(userID, orderID) {
+		return ownErr.NewClientError(fmt.Errorf("user is not order owner"))
+	}
+
+	orderInfo, err := o.orderRepository.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	// Customers may always cancel a freshly created order. An accepted order
+	// can still be cancelled while the cancellation window is open.
+	canCancel := order.CanChangeStatus(orderInfo.Status, models.OrderStatusCancelled, order.ActorCustomer) ||
+		(orderInfo.Status == models.OrderStatusAccepted && time.Since(orderInfo.CreatedAt) <= configs.OrderCancelWindow)
+
+	if !canCancel {
+		return ownErr.NewClientError(
+			fmt.Errorf("%w: order in status %q can't be cancelled anymore", order.ErrWrongStatusTransition, orderInfo.Status),
+		)
+	}
+
+	change := models.OrderStatusChange{
+		Status:    models.OrderStatusCancelled,
+		UserID:    userID,
+		Comment:   reason,
+		ChangedAt: time.Now(),
+	}
+
+	err = o.orderRepository.CancelOrder(orderID, orderInfo.Status, change)
+	if errors.Is(err, order.ErrWrongStatusTransition) {
+		return ownErr.NewClientError(err)
+	}
+
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	o.refund(orderID, userID, reason)
+
+	return nil
+}
+
+// refund returns the money of a cancelled or rejected order. The status
+// change is already saved, so a failed refund doesn't fail the request:
+// the scheduler retries it with RetryRefund.
+func (o OrderUsecase) refund(orderID string, initiatorID string, reason string) {
+	err := o.refundUsecase.RefundWholeOrder(orderID, initiatorID, reason)
+	if err != nil {
+		log.ErrorMessage(fmt.Sprintf("couldn't refund order %v: %v", orderID, err))
+	}
+}
+
+// GetUnrefundedOrders returns cancelled and rejected orders that are still paid.
+func (o OrderUsecase) GetUnrefundedOrders() ([]string, error) {
+	return o.orderRepository.GetUnrefundedOrders()
+}
+
+// RetryRefund refunds a cancelled or rejected order on behalf of whoever
+// made the last status change.
+func (o OrderUsecase) RetryRefund(orderID string) error {
+	history, err := o.orderRepository.GetOrderHistory(orderID)
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		return ownErr.NewServerError(fmt.Errorf("order %v has no status history", orderID))
+	}
+
+	last := history[len(history)-1]
+	return o.refundUsecase.RefundWholeOrder(orderID, last.UserID, last.Comment)
+}
+
+func (o OrderUsecase) GetOrderHistory(userID string, orderID string) ([]models.OrderStatusChange, error) {
+	if !o.orderRepository.CheckOrderByUser(userID, orderID) {
+		orderIDInt, err := strconv.Atoi(orderID)
+		if err != nil {
+			return nil, ownErr.NewClientError(fmt.Errorf("wrong order id: %w", err))
+		}
+
+		vendorID, err := o.orderRepository.GetVendorIDFromOrder(orderIDInt)
+		if err != nil {
+			return nil, err
+		}
+
+		err = o.vendorRepository.CheckVendorOwner(userID, strconv.Itoa(vendorID))
+		if err != nil {
+			return nil, ownErr.NewClientError(fmt.Errorf("user is neither order owner nor vendor partner: %w", err))
+		}
+	}
+
+	return o.orderRepository.GetOrderHistory(orderID)
 }
 
 func (o OrderUsecase) GetVendorIDFromOrder(orderID int) (int, error) {
@@ -100,3 +563,27 @@ func (o OrderUsecase) GetVendorIDFromOrder(orderID int) (int, error) {
 func (o OrderUsecase) GetUserIDFromOrder(orderID int) (string, error) {
 	return o.orderRepository.GetUserIDFromOrder(orderID)
 }
+
+// ReleaseScheduledOrders shows the vendors the scheduled orders with
+// a delivery slot within the lead time.
+func (o OrderUsecase) ReleaseScheduledOrders(lead time.Duration) ([]models.OrderResponse, error) {
+	return o.orderRepository.ReleaseScheduledOrders(time.Now().Add(lead))
+}
+
+// NotifyNewOrder sends the order to the partner owning its vendor.
+func (o OrderUsecase) NotifyNewOrder(orderID int) error {
+	newOrder, err := o.orderRepository.GetOrder(strconv.Itoa(orderID))
+	if err != nil {
+		return err
+	}
+
+	partnerID, err := o.vendorRepository.GetVendorOwner(newOrder.VendorID)
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	return o.eventsUsecase.Publish(partnerID, models.Event{
+		Type:  models.EventNewOrder,
+		Order: &newOrder,
+	})
+}
@@ -0,0 +1,906 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/cart"
+	"github.com/friends/internal/pkg/events"
+	staticGeocoder "github.com/friends/internal/pkg/geocoder/static"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/order"
+	"github.com/friends/internal/pkg/payment"
+	fakePayment "github.com/friends/internal/pkg/payment/fake"
+	"github.com/friends/internal/pkg/refund"
+	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	orderID   = "10"
+	userID    = "1"
+	partnerID = "2"
+	vendorID  = 5
+
+	testGeocoder = staticGeocoder.New(map[string]staticGeocoder.Point{"test addr": {37.6, 55.7}})
+
+	dbError = fmt.Errorf("db error")
+)
+
+func openSchedules(vendorID int) map[int]models.Schedule {
+	return map[int]models.Schedule{vendorID: {TimeZone: "UTC"}}
+}
+
+func TestAddOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockVendorRepo := vendors.NewMockRepository(ctrl)
+	orderUsecase := New(mockOrderRepo, mockVendorRepo, nil, fakePayment.New("secret"), nil, testGeocoder, nil)
+
+	request := models.OrderRequest{
+		ProductIDs: []int{1, 1},
+		Items:      []models.ProductQuantity{{ProductID: 2, Quantity: 3}},
+		Address:    "test addr",
+		Longitude:  37.6,
+		Latitude:   55.7,
+	}
+	settings := models.DeliverySettings{
+		MinOrderAmount: 300,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 5000, Fee: 150}, {MaxDistance: 2000, Fee: 50}},
+	}
+	products := []models.Product{
+		{ID: 1, Name: "borscht", Price: 200, VendorID: vendorID},
+		{ID: 2, Name: "bread", Price: 10, VendorID: vendorID},
+	}
+
+	expected := request
+	expected.VendorID = vendorID
+	expected.VendorName = "test"
+	expected.Price = 2*200 + 3*10
+	expected.Products = []models.OrderProduct{
+		{Name: "borscht", Price: 200, Quantity: 2},
+		{Name: "bread", Price: 10, Quantity: 3},
+	}
+	expected.Distance = 3200
+	expected.DeliveryFee = 150
+
+	// good order
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(settings, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(true, nil)
+	mockVendorRepo.EXPECT().GetDistance(vendorID, 37.6, 55.7).Times(1).Return(3200, nil)
+	mockOrderRepo.EXPECT().AddOrder(userID, expected).Times(1).Return(10, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_fake_10").Times(1).Return(nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_fake_10", models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	id, err := orderUsecase.AddOrder(userID, request)
+
+	if id != 10 {
+		t.Errorf("expected: %v\n got: %v", 10, id)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// below minimum order amount
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(models.DeliverySettings{MinOrderAmount: 1000}, nil)
+
+	_, err = orderUsecase.AddOrder(userID, request)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// too far for delivery
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(settings, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(true, nil)
+	mockVendorRepo.EXPECT().GetDistance(vendorID, 37.6, 55.7).Times(1).Return(7000, nil)
+
+	_, err = orderUsecase.AddOrder(userID, request)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// outside service area
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(settings, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(false, nil)
+
+	_, err = orderUsecase.AddOrder(userID, request)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// unknown address without coordinates
+	noCoordinates := request
+	noCoordinates.Address = "unknown addr"
+	noCoordinates.Longitude, noCoordinates.Latitude = 0, 0
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(settings, nil)
+
+	_, err = orderUsecase.AddOrder(userID, noCoordinates)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// scheduled order
+	scheduled := request
+	scheduled.DeliverAt = time.Now().Add(time.Hour * 2)
+	expectedScheduled := expected
+	expectedScheduled.DeliverAt = scheduled.DeliverAt
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(settings, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(true, nil)
+	mockVendorRepo.EXPECT().GetDistance(vendorID, 37.6, 55.7).Times(1).Return(3200, nil)
+	mockOrderRepo.EXPECT().AddOrder(userID, expectedScheduled).Times(1).Return(11, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(11, "pi_fake_11").Times(1).Return(nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_fake_11", models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	id, err = orderUsecase.AddOrder(userID, scheduled)
+
+	if id != 11 {
+		t.Errorf("expected: %v\n got: %v", 11, id)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// scheduled too soon
+	tooSoon := request
+	tooSoon.DeliverAt = time.Now().Add(time.Minute)
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+
+	_, err = orderUsecase.AddOrder(userID, tooSoon)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// scheduled on a holiday
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(
+		map[int]models.Schedule{vendorID: {
+			TimeZone: "UTC",
+			Holidays: []string{scheduled.DeliverAt.UTC().Format(configs.HolidayFormat)},
+		}}, nil,
+	)
+
+	_, err = orderUsecase.AddOrder(userID, scheduled)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// paused vendor
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(
+		map[int]models.Schedule{vendorID: {TimeZone: "UTC", Paused: true}}, nil,
+	)
+
+	_, err = orderUsecase.AddOrder(userID, request)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// unknown product
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1, 2}).Times(1).Return(products[:1], nil)
+
+	_, err = orderUsecase.AddOrder(userID, request)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// wrong quantity
+	_, err = orderUsecase.AddOrder(userID, models.OrderRequest{
+		Items: []models.ProductQuantity{{ProductID: 2, Quantity: 0}},
+	})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// empty order
+	_, err = orderUsecase.AddOrder(userID, models.OrderRequest{})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockVendorRepo := vendors.NewMockRepository(ctrl)
+	mockCartRepo := cart.NewMockRepository(ctrl)
+	orderUsecase := New(mockOrderRepo, mockVendorRepo, mockCartRepo, fakePayment.New("secret"), nil, testGeocoder, nil)
+
+	request := models.OrderRequest{
+		ProductIDs: []int{7},
+		Address:    "test addr",
+	}
+	cartProducts := []models.ProductQuantity{{ProductID: 1, Quantity: 2}}
+	products := []models.Product{{ID: 1, Name: "borscht", Price: 200, VendorID: vendorID}}
+
+	expected := models.OrderRequest{
+		Items:      cartProducts,
+		Address:    "test addr",
+		Longitude:  37.6,
+		Latitude:   55.7,
+		Distance:   800,
+		VendorID:   vendorID,
+		VendorName: "test",
+		Price:      400,
+		Products:   []models.OrderProduct{{Name: "borscht", Price: 200, Quantity: 2}},
+	}
+
+	// good checkout
+	mockCartRepo.EXPECT().GetProducts(userID).Times(1).Return(cartProducts, nil)
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1}).Times(1).Return(products, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(models.DeliverySettings{}, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(true, nil)
+	mockVendorRepo.EXPECT().GetDistance(vendorID, 37.6, 55.7).Times(1).Return(800, nil)
+	mockOrderRepo.EXPECT().AddOrderFromCart(userID, expected).Times(1).Return(10, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_fake_10").Times(1).Return(nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_fake_10", models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	id, err := orderUsecase.Checkout(userID, request)
+
+	if id != 10 {
+		t.Errorf("expected: %v\n got: %v", 10, id)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// declined payment cancels the order and restores the cart
+	declinedProducts := []models.Product{{ID: 1, Name: "borscht", Price: 300 + fakePayment.DeclinedAmountSuffix, VendorID: vendorID}}
+	singleItem := []models.ProductQuantity{{ProductID: 1, Quantity: 1}}
+
+	mockCartRepo.EXPECT().GetProducts(userID).Times(1).Return(singleItem, nil)
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID}).Times(1).Return(openSchedules(vendorID), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1}).Times(1).Return(declinedProducts, nil)
+	mockVendorRepo.EXPECT().GetDeliverySettings(vendorID).Times(1).Return(models.DeliverySettings{}, nil)
+	mockVendorRepo.EXPECT().IsInServiceArea(vendorID, 37.6, 55.7).Times(1).Return(true, nil)
+	mockVendorRepo.EXPECT().GetDistance(vendorID, 37.6, 55.7).Times(1).Return(800, nil)
+	mockOrderRepo.EXPECT().AddOrderFromCart(userID, gomock.Any()).Times(1).Return(11, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(11, "pi_fake_11").Times(1).Return(nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_fake_11", models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(true, nil)
+	mockOrderRepo.EXPECT().CancelOrder("11", models.OrderStatusCreated, gomock.Any()).Times(1).Return(nil)
+	mockCartRepo.EXPECT().Add(userID, "1", strconv.Itoa(vendorID), 1).Times(1).Return(nil)
+
+	id, err = orderUsecase.Checkout(userID, request)
+
+	if id != 0 {
+		t.Errorf("expected: %v\n got: %v", 0, id)
+	}
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// product moved to another vendor
+	mockCartRepo.EXPECT().GetProducts(userID).Times(1).Return(cartProducts, nil)
+	mockVendorRepo.EXPECT().GetVendorFromProduct(1).Times(1).Return(models.Vendor{ID: vendorID + 1, Name: "test"}, nil)
+	mockVendorRepo.EXPECT().GetSchedules([]int{vendorID + 1}).Times(1).Return(openSchedules(vendorID+1), nil)
+	mockVendorRepo.EXPECT().GetAllProductsWithIDsFromSameVendor([]int{1}).Times(1).Return(products, nil)
+
+	_, err = orderUsecase.Checkout(userID, request)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// empty cart
+	mockCartRepo.EXPECT().GetProducts(userID).Times(1).Return(nil, nil)
+
+	_, err = orderUsecase.Checkout(userID, request)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// db error
+	mockCartRepo.EXPECT().GetProducts(userID).Times(1).Return(nil, dbError)
+
+	_, err = orderUsecase.Checkout(userID, request)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestPayOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockProvider := payment.NewMockProvider(ctrl)
+	orderUsecase := OrderUsecase{orderRepository: mockOrderRepo, paymentProvider: mockProvider}
+
+	intent := models.PaymentIntent{ID: "pi_1", OrderID: 10, Amount: 400, Status: models.PaymentStatusPending}
+
+	// capture confirmed
+	captured := intent
+	captured.Status = models.PaymentStatusPaid
+
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(nil)
+	mockProvider.EXPECT().Capture("pi_1").Times(1).Return(captured, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	cancelled, err := orderUsecase.pay(userID, 10, 400)
+
+	if cancelled || err != nil {
+		t.Errorf("unexpected result: %v, %v", cancelled, err)
+	}
+
+	// capture waits for webhook
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(nil)
+	mockProvider.EXPECT().Capture("pi_1").Times(1).Return(intent, nil)
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	if cancelled || err != nil {
+		t.Errorf("unexpected result: %v, %v", cancelled, err)
+	}
+
+	// capture declined
+	declined := intent
+	declined.Status = models.PaymentStatusFailed
+
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(nil)
+	mockProvider.EXPECT().Capture("pi_1").Times(1).Return(declined, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(true, nil)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).Return(nil)
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !cancelled || !ok || !reqErr.IsClientError() {
+		t.Errorf("expected cancelled order and client error. Got: %v, %v", cancelled, err)
+	}
+
+	// intent isn't created, nothing is charged
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(models.PaymentIntent{}, fmt.Errorf("unavailable"))
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).Return(nil)
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !cancelled || !ok || !reqErr.IsServerError() {
+		t.Errorf("expected cancelled order and server error. Got: %v, %v", cancelled, err)
+	}
+
+	// unpaid order can't be cancelled
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(dbError)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).Return(dbError)
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if cancelled || !ok || !reqErr.IsServerError() {
+		t.Errorf("expected server error. Got: %v, %v", cancelled, err)
+	}
+
+	// capture result is unknown, the order stays pending
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(nil)
+	mockProvider.EXPECT().Capture("pi_1").Times(1).Return(models.PaymentIntent{}, fmt.Errorf("timeout"))
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if cancelled || !ok || !reqErr.IsServerError() {
+		t.Errorf("expected pending order and server error. Got: %v, %v", cancelled, err)
+	}
+
+	// captured payment isn't saved, the order stays pending
+	mockProvider.EXPECT().CreateIntent(10, 400).Times(1).Return(intent, nil)
+	mockOrderRepo.EXPECT().SetPaymentIntent(10, "pi_1").Times(1).Return(nil)
+	mockProvider.EXPECT().Capture("pi_1").Times(1).Return(captured, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_1", models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(false, dbError)
+
+	cancelled, err = orderUsecase.pay(userID, 10, 400)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if cancelled || !ok || !reqErr.IsServerError() {
+		t.Errorf("expected pending order and server error. Got: %v, %v", cancelled, err)
+	}
+}
+
+func TestHandlePaymentWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	provider := fakePayment.New("secret")
+	orderUsecase := New(mockOrderRepo, nil, nil, provider, nil, nil, nil)
+
+	intent, _ := provider.CreateIntent(10, 313)
+	intent, _ = provider.Capture(intent.ID)
+
+	if intent.Status != models.PaymentStatusFailed {
+		t.Errorf("expected: %v\n got: %v", models.PaymentStatusFailed, intent.Status)
+	}
+
+	payload, signature, err := provider.(fakePayment.Provider).Webhook(intent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// failed payment cancels the order
+	mockOrderRepo.EXPECT().UpdatePaymentStatus(intent.ID, models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(true, nil)
+	mockOrderRepo.EXPECT().GetUserIDFromOrder(10).Times(1).Return(userID, nil)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).Return(nil)
+
+	err = orderUsecase.HandlePaymentWebhook(payload, signature)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// order already cancelled by the customer
+	mockOrderRepo.EXPECT().UpdatePaymentStatus(intent.ID, models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(true, nil)
+	mockOrderRepo.EXPECT().GetUserIDFromOrder(10).Times(1).Return(userID, nil)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).
+		Return(fmt.Errorf("%w: order is no longer in status %q", order.ErrWrongStatusTransition, models.OrderStatusCreated))
+
+	err = orderUsecase.HandlePaymentWebhook(payload, signature)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// repeated webhook is ignored
+	mockOrderRepo.EXPECT().UpdatePaymentStatus(intent.ID, models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(false, nil)
+
+	err = orderUsecase.HandlePaymentWebhook(payload, signature)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// paid order isn't cancelled
+	paid, _ := provider.CreateIntent(12, 400)
+	paid, _ = provider.Capture(paid.ID)
+	paidPayload, paidSignature, _ := provider.(fakePayment.Provider).Webhook(paid.ID)
+
+	mockOrderRepo.EXPECT().UpdatePaymentStatus(paid.ID, models.PaymentStatusPending, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	err = orderUsecase.HandlePaymentWebhook(paidPayload, paidSignature)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// db error
+	mockOrderRepo.EXPECT().UpdatePaymentStatus(intent.ID, models.PaymentStatusPending, models.PaymentStatusFailed).Times(1).Return(false, dbError)
+
+	err = orderUsecase.HandlePaymentWebhook(payload, signature)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// wrong signature
+	err = orderUsecase.HandlePaymentWebhook(payload, "00"+signature[2:])
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockRefundUsecase := refund.NewMockUsecase(ctrl)
+	orderUsecase := New(mockOrderRepo, nil, nil, nil, mockRefundUsecase, nil, nil)
+
+	// allowed transition
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+	mockOrderRepo.EXPECT().UpdateOrderStatus(orderID, models.OrderStatusCreated, models.OrderStatusChange{Status: models.OrderStatusAccepted}).Times(1).Return(nil)
+
+	err := orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusAccepted}, order.ActorPartner)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// rejected order is refunded
+	rejection := models.OrderStatusChange{Status: models.OrderStatusRejected, UserID: partnerID, Comment: "no bread"}
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+	mockOrderRepo.EXPECT().UpdateOrderStatus(orderID, models.OrderStatusCreated, rejection).Times(1).Return(nil)
+	mockRefundUsecase.EXPECT().RefundWholeOrder(orderID, partnerID, "no bread").Times(1).Return(nil)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, rejection, order.ActorPartner)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// failed refund doesn't fail the saved transition
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+	mockOrderRepo.EXPECT().UpdateOrderStatus(orderID, models.OrderStatusCreated, rejection).Times(1).Return(nil)
+	mockRefundUsecase.EXPECT().RefundWholeOrder(orderID, partnerID, "no bread").Times(1).Return(dbError)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, rejection, order.ActorPartner)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// skipped step
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusDelivered}, order.ActorPartner)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// wrong actor
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusAccepted}, order.ActorCustomer)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// unknown status
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusAccepted, nil)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: "ready"}, order.ActorPartner)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// db error on update
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCooking, nil)
+	mockOrderRepo.EXPECT().UpdateOrderStatus(orderID, models.OrderStatusCooking, models.OrderStatusChange{Status: models.OrderStatusDelivering}).Times(1).Return(dbError)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusDelivering}, order.ActorPartner)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// status changed concurrently
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return(models.OrderStatusCreated, nil)
+	mockOrderRepo.EXPECT().
+		UpdateOrderStatus(orderID, models.OrderStatusCreated, models.OrderStatusChange{Status: models.OrderStatusAccepted}).
+		Times(1).Return(fmt.Errorf("%w: order is no longer in status", order.ErrWrongStatusTransition))
+
+	err = orderUsecase.UpdateOrderStatus(
+		strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusAccepted}, order.ActorPartner,
+	)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// db error on status check
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockOrderRepo.EXPECT().GetOrderStatus(orderID).Times(1).Return("", dbError)
+
+	err = orderUsecase.UpdateOrderStatus(strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusDelivering}, order.ActorPartner)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// order of another vendor
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID+1, nil)
+
+	err = orderUsecase.UpdateOrderStatus(
+		strconv.Itoa(vendorID), orderID, models.OrderStatusChange{Status: models.OrderStatusAccepted}, order.ActorPartner,
+	)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockRefundUsecase := refund.NewMockUsecase(ctrl)
+	orderUsecase := New(mockOrderRepo, nil, nil, nil, mockRefundUsecase, nil, nil)
+
+	reason := "changed my mind"
+
+	// created order
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(
+		models.OrderResponse{Status: models.OrderStatusCreated, CreatedAt: time.Now().Add(-time.Hour)}, nil,
+	)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).Times(1).Return(nil)
+	mockRefundUsecase.EXPECT().RefundWholeOrder(orderID, userID, reason).Times(1).Return(nil)
+
+	err := orderUsecase.CancelOrder(userID, orderID, reason)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// accepted order inside the window
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(
+		models.OrderResponse{Status: models.OrderStatusAccepted, CreatedAt: time.Now()}, nil,
+	)
+	mockOrderRepo.EXPECT().CancelOrder(orderID, models.OrderStatusAccepted, gomock.Any()).Times(1).Return(nil)
+	mockRefundUsecase.EXPECT().RefundWholeOrder(orderID, userID, reason).Times(1).Return(nil)
+
+	err = orderUsecase.CancelOrder(userID, orderID, reason)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// order changed status concurrently
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(
+		models.OrderResponse{Status: models.OrderStatusCreated, CreatedAt: time.Now()}, nil,
+	)
+	mockOrderRepo.EXPECT().
+		CancelOrder(orderID, models.OrderStatusCreated, gomock.Any()).
+		Times(1).Return(fmt.Errorf("%w: order is no longer in status", order.ErrWrongStatusTransition))
+
+	err = orderUsecase.CancelOrder(userID, orderID, reason)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// accepted order after the window
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(
+		models.OrderResponse{Status: models.OrderStatusAccepted, CreatedAt: time.Now().Add(-time.Hour)}, nil,
+	)
+
+	err = orderUsecase.CancelOrder(userID, orderID, reason)
+
+	reqErr, ok = err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// cooking order
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(
+		models.OrderResponse{Status: models.OrderStatusCooking, CreatedAt: time.Now()}, nil,
+	)
+
+	err = orderUsecase.CancelOrder(userID, orderID, reason)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// not owner
+	mockOrderRepo.EXPECT().CheckOrderByUser(partnerID, orderID).Times(1).Return(false)
+
+	err = orderUsecase.CancelOrder(partnerID, orderID, reason)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestRetryRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockRefundUsecase := refund.NewMockUsecase(ctrl)
+	orderUsecase := New(mockOrderRepo, nil, nil, nil, mockRefundUsecase, nil, nil)
+
+	history := []models.OrderStatusChange{
+		{Status: models.OrderStatusCreated, UserID: userID},
+		{Status: models.OrderStatusRejected, UserID: partnerID, Comment: "no bread"},
+	}
+
+	// refund on behalf of the last status change
+	mockOrderRepo.EXPECT().GetOrderHistory(orderID).Times(1).Return(history, nil)
+	mockRefundUsecase.EXPECT().RefundWholeOrder(orderID, partnerID, "no bread").Times(1).Return(nil)
+
+	err := orderUsecase.RetryRefund(orderID)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// no history
+	mockOrderRepo.EXPECT().GetOrderHistory(orderID).Times(1).Return(nil, nil)
+
+	err = orderUsecase.RetryRefund(orderID)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// db error
+	mockOrderRepo.EXPECT().GetOrderHistory(orderID).Times(1).Return(nil, dbError)
+
+	err = orderUsecase.RetryRefund(orderID)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockVendorRepo := vendors.NewMockRepository(ctrl)
+	orderUsecase := New(mockOrderRepo, mockVendorRepo, nil, nil, nil, nil, nil)
+
+	history := []models.OrderStatusChange{
+		{Status: models.OrderStatusCreated, UserID: userID},
+	}
+
+	// customer
+	mockOrderRepo.EXPECT().CheckOrderByUser(userID, orderID).Times(1).Return(true)
+	mockOrderRepo.EXPECT().GetOrderHistory(orderID).Times(1).Return(history, nil)
+
+	got, err := orderUsecase.GetOrderHistory(userID, orderID)
+
+	if !reflect.DeepEqual(history, got) {
+		t.Errorf("expected: %v\n got: %v", history, got)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// vendor partner
+	mockOrderRepo.EXPECT().CheckOrderByUser(partnerID, orderID).Times(1).Return(false)
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, strconv.Itoa(vendorID)).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrderHistory(orderID).Times(1).Return(history, nil)
+
+	_, err = orderUsecase.GetOrderHistory(partnerID, orderID)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// stranger
+	mockOrderRepo.EXPECT().CheckOrderByUser(partnerID, orderID).Times(1).Return(false)
+	mockOrderRepo.EXPECT().GetVendorIDFromOrder(10).Times(1).Return(vendorID, nil)
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, strconv.Itoa(vendorID)).Times(1).Return(dbError)
+
+	_, err = orderUsecase.GetOrderHistory(partnerID, orderID)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+}
+
+func TestNotifyNewOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockVendorRepo := vendors.NewMockRepository(ctrl)
+	mockEventsUsecase := events.NewMockUsecase(ctrl)
+	orderUsecase := New(mockOrderRepo, mockVendorRepo, nil, nil, nil, nil, mockEventsUsecase)
+
+	newOrder := models.OrderResponse{ID: 10, VendorID: vendorID, Status: models.OrderStatusCreated}
+
+	// partner gets the order
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(newOrder, nil)
+	mockVendorRepo.EXPECT().GetVendorOwner(vendorID).Times(1).Return(partnerID, nil)
+	mockEventsUsecase.EXPECT().Publish(partnerID, models.Event{Type: models.EventNewOrder, Order: &newOrder}).
+		Times(1).Return(nil)
+
+	err := orderUsecase.NotifyNewOrder(10)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// db error
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(models.OrderResponse{}, dbError)
+
+	err = orderUsecase.NotifyNewOrder(10)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestCanChangeStatus(t *testing.T) {
+	cases := []struct {
+		from     string
+		to       string
+		actor    string
+		expected bool
+	}{
+		{models.OrderStatusCreated, models.OrderStatusAccepted, order.ActorPartner, true},
+		{models.OrderStatusCreated, models.OrderStatusCancelled, order.ActorCustomer, true},
+		{models.OrderStatusCreated, models.OrderStatusCancelled, order.ActorPartner, false},
+		{models.OrderStatusAccepted, models.OrderStatusCooking, order.ActorPartner, true},
+		{models.OrderStatusCooking, models.OrderStatusDelivering, order.ActorPartner, true},
+		{models.OrderStatusDelivering, models.OrderStatusDelivered, order.ActorSystem, true},
+		{models.OrderStatusDelivered, models.OrderStatusCancelled, order.ActorPartner, false},
+		{models.OrderStatusRejected, models.OrderStatusAccepted, order.ActorPartner, false},
+	}
+
+	for _, c := range cases {
+		got := order.CanChangeStatus(c.from, c.to, c.actor)
+		if got != c.expected {
+			t.Errorf("%v -> %v by %v. expected: %v\n got: %v", c.from, c.to, c.actor, c.expected, got)
+		}
+	}
+}
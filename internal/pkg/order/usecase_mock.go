@@ -8,6 +8,7 @@ import (
 	models "github.com/friends/internal/pkg/models"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockUsecase is a mock of Usecase interface
@@ -48,6 +49,35 @@ func (mr *MockUsecaseMockRecorder) AddOrder(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockUsecase)(nil).AddOrder), arg0, arg1)
 }
 
+// CancelOrder mocks base method
+func (m *MockUsecase) CancelOrder(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder
+func (mr *MockUsecaseMockRecorder) CancelOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockUsecase)(nil).CancelOrder), arg0, arg1, arg2)
+}
+
+// Checkout mocks base method
+func (m *MockUsecase) Checkout(arg0 string, arg1 models.OrderRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout
+func (mr *MockUsecaseMockRecorder) Checkout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockUsecase)(nil).Checkout), arg0, arg1)
+}
+
 // GetOrder mocks base method
 func (m *MockUsecase) GetOrder(arg0, arg1 string) (models.OrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +93,36 @@ func (mr *MockUsecaseMockRecorder) GetOrder(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockUsecase)(nil).GetOrder), arg0, arg1)
 }
 
+// GetOrderHistory mocks base method
+func (m *MockUsecase) GetOrderHistory(arg0, arg1 string) ([]models.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderHistory", arg0, arg1)
+	ret0, _ := ret[0].([]models.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderHistory indicates an expected call of GetOrderHistory
+func (mr *MockUsecaseMockRecorder) GetOrderHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderHistory", reflect.TypeOf((*MockUsecase)(nil).GetOrderHistory), arg0, arg1)
+}
+
+// GetUnrefundedOrders mocks base method
+func (m *MockUsecase) GetUnrefundedOrders() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnrefundedOrders")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnrefundedOrders indicates an expected call of GetUnrefundedOrders
+func (mr *MockUsecaseMockRecorder) GetUnrefundedOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnrefundedOrders", reflect.TypeOf((*MockUsecase)(nil).GetUnrefundedOrders))
+}
+
 // GetUserIDFromOrder mocks base method
 func (m *MockUsecase) GetUserIDFromOrder(arg0 int) (string, error) {
 	m.ctrl.T.Helper()
@@ -123,16 +183,73 @@ func (mr *MockUsecaseMockRecorder) GetVendorOrders(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorOrders", reflect.TypeOf((*MockUsecase)(nil).GetVendorOrders), arg0)
 }
 
+// HandlePaymentWebhook mocks base method
+func (m *MockUsecase) HandlePaymentWebhook(arg0 []byte, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePaymentWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandlePaymentWebhook indicates an expected call of HandlePaymentWebhook
+func (mr *MockUsecaseMockRecorder) HandlePaymentWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePaymentWebhook", reflect.TypeOf((*MockUsecase)(nil).HandlePaymentWebhook), arg0, arg1)
+}
+
+// NotifyNewOrder mocks base method
+func (m *MockUsecase) NotifyNewOrder(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyNewOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyNewOrder indicates an expected call of NotifyNewOrder
+func (mr *MockUsecaseMockRecorder) NotifyNewOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyNewOrder", reflect.TypeOf((*MockUsecase)(nil).NotifyNewOrder), arg0)
+}
+
+// ReleaseScheduledOrders mocks base method
+func (m *MockUsecase) ReleaseScheduledOrders(arg0 time.Duration) ([]models.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseScheduledOrders", arg0)
+	ret0, _ := ret[0].([]models.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseScheduledOrders indicates an expected call of ReleaseScheduledOrders
+func (mr *MockUsecaseMockRecorder) ReleaseScheduledOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseScheduledOrders", reflect.TypeOf((*MockUsecase)(nil).ReleaseScheduledOrders), arg0)
+}
+
+// RetryRefund mocks base method
+func (m *MockUsecase) RetryRefund(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryRefund", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryRefund indicates an expected call of RetryRefund
+func (mr *MockUsecaseMockRecorder) RetryRefund(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryRefund", reflect.TypeOf((*MockUsecase)(nil).RetryRefund), arg0)
+}
+
 // UpdateOrderStatus mocks base method
-func (m *MockUsecase) UpdateOrderStatus(arg0, arg1 string) error {
+func (m *MockUsecase) UpdateOrderStatus(arg0, arg1 string, arg2 models.OrderStatusChange, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus
-func (mr *MockUsecaseMockRecorder) UpdateOrderStatus(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) UpdateOrderStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockUsecase)(nil).UpdateOrderStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockUsecase)(nil).UpdateOrderStatus), arg0, arg1, arg2, arg3)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/friends/internal/pkg/session"
 	"github.com/friends/internal/pkg/user"
 	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 )
@@ -479,6 +480,240 @@ func TestUpdateVendorNoPartnerID(t *testing.T) {
 	}
 }
 
+func TestUpdateDeliverySettingsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	settings := models.DeliverySettings{
+		MinOrderAmount: 500,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 2000, Fee: 50}},
+	}
+
+	settingsJson, _ := json.Marshal(&settings)
+	body := bytes.NewReader(settingsJson)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().UpdateDeliverySettings(1, settings).Times(1).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/delivery", body)
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateDeliverySettings(w, r.WithContext(ctx))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestUpdateDeliverySettingsWrongTiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	settings := models.DeliverySettings{
+		FeeTiers: []models.DeliveryFeeTier{{MaxDistance: -1, Fee: 50}},
+	}
+
+	settingsJson, _ := json.Marshal(&settings)
+	body := bytes.NewReader(settingsJson)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().UpdateDeliverySettings(1, settings).Times(1).Return(
+		ownErr.NewClientError(vendors.ErrWrongFeeSettings),
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/delivery", body)
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateDeliverySettings(w, r.WithContext(ctx))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestUpdateDeliverySettingsNotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(fmt.Errorf("err"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/delivery", bytes.NewReader([]byte(`{}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateDeliverySettings(w, r.WithContext(ctx))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestUpdateDeliveryZoneSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	zone := models.DeliveryZone{
+		Type:        models.GeoJSONMultiPolygon,
+		Coordinates: [][][][2]float64{{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.7}}}},
+	}
+
+	zoneJson, _ := json.Marshal(&zone)
+	body := bytes.NewReader(zoneJson)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().UpdateDeliveryZone(1, zone).Times(1).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/zone", body)
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateDeliveryZone(w, r.WithContext(ctx))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestUpdateDeliveryZoneWrongZone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	zone := models.DeliveryZone{Type: "Point"}
+
+	zoneJson, _ := json.Marshal(&zone)
+	body := bytes.NewReader(zoneJson)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().UpdateDeliveryZone(1, zone).Times(1).Return(
+		ownErr.NewClientError(vendors.ErrWrongZone),
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/zone", body)
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateDeliveryZone(w, r.WithContext(ctx))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestUpdateScheduleWrongSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	schedule := models.Schedule{TimeZone: "Mars/Olympus"}
+
+	scheduleJson, _ := json.Marshal(&schedule)
+	body := bytes.NewReader(scheduleJson)
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().UpdateSchedule(1, schedule).Times(1).Return(
+		ownErr.NewClientError(vendors.ErrWrongSchedule),
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/schedule", body)
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.UpdateSchedule(w, r.WithContext(ctx))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestSetPausedSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+
+	handler := PartnerDelivery{
+		vendorUsecase: mockVendorUsecase,
+	}
+
+	partnerID, vendorID := "0", "1"
+
+	mockVendorUsecase.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockVendorUsecase.EXPECT().SetPaused(1, true).Times(1).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/vendors/1/pause", bytes.NewReader([]byte(`{"paused":true}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": vendorID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.SetPaused(w, r.WithContext(ctx))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
 func TestAddProductSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
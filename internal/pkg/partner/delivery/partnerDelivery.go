@@ -14,6 +14,7 @@ import (
 	"github.com/friends/internal/pkg/session"
 	"github.com/friends/internal/pkg/user"
 	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
 	"github.com/friends/pkg/httputils"
 	"github.com/friends/pkg/image"
 	log "github.com/friends/pkg/logger"
@@ -177,6 +178,178 @@ func (p PartnerDelivery) UpdateVendor(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (p PartnerDelivery) UpdateDeliverySettings(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	vendorID := mux.Vars(r)["id"]
+
+	err = p.vendorUsecase.CheckVendorOwner(userID, vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vendorIDInt, err := strconv.Atoi(vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	settings := models.DeliverySettings{}
+	err = json.NewDecoder(r.Body).Decode(&settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = p.vendorUsecase.UpdateDeliverySettings(vendorIDInt, settings)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+}
+
+func (p PartnerDelivery) UpdateDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	vendorID := mux.Vars(r)["id"]
+
+	err = p.vendorUsecase.CheckVendorOwner(userID, vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vendorIDInt, err := strconv.Atoi(vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	zone := models.DeliveryZone{}
+	err = json.NewDecoder(r.Body).Decode(&zone)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = p.vendorUsecase.UpdateDeliveryZone(vendorIDInt, zone)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+}
+
+func (p PartnerDelivery) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	vendorID := mux.Vars(r)["id"]
+
+	err = p.vendorUsecase.CheckVendorOwner(userID, vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vendorIDInt, err := strconv.Atoi(vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	schedule := models.Schedule{}
+	err = json.NewDecoder(r.Body).Decode(&schedule)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = p.vendorUsecase.UpdateSchedule(vendorIDInt, schedule)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+}
+
+func (p PartnerDelivery) SetPaused(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	userID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	vendorID := mux.Vars(r)["id"]
+
+	err = p.vendorUsecase.CheckVendorOwner(userID, vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vendorIDInt, err := strconv.Atoi(vendorID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	request := models.PauseRequest{}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = p.vendorUsecase.SetPaused(vendorIDInt, request.Paused)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusBadRequest)
+		return
+	}
+}
+
 func (p PartnerDelivery) AddProductToVendor(w http.ResponseWriter, r *http.Request) {
 	var err error
 	defer func() {
@@ -0,0 +1,143 @@
+package fake
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/payment"
+)
+
+// DeclinedAmountSuffix makes the fake provider decline captures of amounts
+// ending with it, so the failed payment flow can be tested offline.
+const DeclinedAmountSuffix = 13
+
+// Provider is a deterministic in-memory payment provider. Intent ids are
+// derived from order ids and webhooks are signed with HMAC-SHA256.
+type Provider struct {
+	secret  []byte
+	mu      *sync.Mutex
+	intents map[string]models.PaymentIntent
+}
+
+func New(secret string) payment.Provider {
+	return Provider{
+		secret:  []byte(secret),
+		mu:      &sync.Mutex{},
+		intents: make(map[string]models.PaymentIntent),
+	}
+}
+
+func (p Provider) CreateIntent(orderID int, amount int) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intentID := fmt.Sprintf("pi_fake_%d", orderID)
+	if intent, ok := p.intents[intentID]; ok {
+		return intent, nil
+	}
+
+	intent := models.PaymentIntent{
+		ID:      intentID,
+		OrderID: orderID,
+		Amount:  amount,
+		Status:  models.PaymentStatusPending,
+	}
+	p.intents[intentID] = intent
+
+	return intent, nil
+}
+
+func (p Provider) Capture(intentID string) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return models.PaymentIntent{}, payment.ErrUnknownIntent
+	}
+
+	if intent.Status != models.PaymentStatusPending {
+		return intent, payment.ErrIntentNotCapture
+	}
+
+	intent.Status = models.PaymentStatusPaid
+	if intent.Amount%100 == DeclinedAmountSuffix {
+		intent.Status = models.PaymentStatusFailed
+	}
+	p.intents[intentID] = intent
+
+	return intent, nil
+}
+
+func (p Provider) Refund(intentID string, amount int) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return models.PaymentIntent{}, payment.ErrUnknownIntent
+	}
+
+	if intent.Status != models.PaymentStatusPaid || amount <= 0 || intent.Refunded+amount > intent.Amount {
+		return intent, payment.ErrWrongRefund
+	}
+
+	intent.Refunded += amount
+	if intent.Refunded == intent.Amount {
+		intent.Status = models.PaymentStatusRefunded
+	}
+	p.intents[intentID] = intent
+
+	return intent, nil
+}
+
+func (p Provider) VerifyWebhook(payload []byte, signature string) (models.PaymentEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.sign(payload)) {
+		return models.PaymentEvent{}, payment.ErrWrongSignature
+	}
+
+	event := models.PaymentEvent{}
+	err = event.UnmarshalJSON(payload)
+	if err != nil {
+		return models.PaymentEvent{}, fmt.Errorf("couldn't parse webhook payload: %w", err)
+	}
+
+	return event, nil
+}
+
+// Webhook returns the payload and signature of the event the fake provider
+// would send for the intent in its current state.
+func (p Provider) Webhook(intentID string) ([]byte, string, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	p.mu.Unlock()
+
+	if !ok {
+		return nil, "", payment.ErrUnknownIntent
+	}
+
+	event := models.PaymentEvent{
+		IntentID: intent.ID,
+		OrderID:  intent.OrderID,
+		Amount:   intent.Amount,
+		Status:   intent.Status,
+	}
+
+	payload, err := event.MarshalJSON()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, hex.EncodeToString(p.sign(payload)), nil
+}
+
+func (p Provider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/payment"
+)
+
+func TestPaymentFlow(t *testing.T) {
+	provider := New("secret")
+
+	intent, err := provider.CreateIntent(10, 400)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// same order gets the same intent
+	again, _ := provider.CreateIntent(10, 400)
+	if again.ID != intent.ID {
+		t.Errorf("expected: %v\n got: %v", intent.ID, again.ID)
+	}
+
+	intent, err = provider.Capture(intent.ID)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if intent.Status != models.PaymentStatusPaid {
+		t.Errorf("expected: %v\n got: %v", models.PaymentStatusPaid, intent.Status)
+	}
+
+	// second capture
+	_, err = provider.Capture(intent.ID)
+	if err != payment.ErrIntentNotCapture {
+		t.Errorf("expected: %v\n got: %v", payment.ErrIntentNotCapture, err)
+	}
+
+	// partial refund
+	intent, err = provider.Refund(intent.ID, 100)
+	if err != nil || intent.Status != models.PaymentStatusPaid || intent.Refunded != 100 {
+		t.Errorf("unexpected refund result: %v, %v", intent, err)
+	}
+
+	// refund more than left
+	_, err = provider.Refund(intent.ID, 400)
+	if err != payment.ErrWrongRefund {
+		t.Errorf("expected: %v\n got: %v", payment.ErrWrongRefund, err)
+	}
+
+	intent, err = provider.Refund(intent.ID, 300)
+	if err != nil || intent.Status != models.PaymentStatusRefunded {
+		t.Errorf("unexpected refund result: %v, %v", intent, err)
+	}
+}
+
+func TestDeclinedCapture(t *testing.T) {
+	provider := New("secret")
+
+	intent, _ := provider.CreateIntent(11, 313)
+	intent, err := provider.Capture(intent.ID)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if intent.Status != models.PaymentStatusFailed {
+		t.Errorf("expected: %v\n got: %v", models.PaymentStatusFailed, intent.Status)
+	}
+
+	_, err = provider.Capture("pi_unknown")
+	if err != payment.ErrUnknownIntent {
+		t.Errorf("expected: %v\n got: %v", payment.ErrUnknownIntent, err)
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	provider := New("secret").(Provider)
+
+	intent, _ := provider.CreateIntent(12, 500)
+	intent, _ = provider.Capture(intent.ID)
+
+	payload, signature, err := provider.Webhook(intent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event, err := provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := models.PaymentEvent{IntentID: intent.ID, OrderID: 12, Amount: 500, Status: models.PaymentStatusPaid}
+	if event != expected {
+		t.Errorf("expected: %v\n got: %v", expected, event)
+	}
+
+	// signed with another secret
+	signature = hex.EncodeToString(New("other").(Provider).sign(payload))
+	_, err = provider.VerifyWebhook(payload, signature)
+	if err != payment.ErrWrongSignature {
+		t.Errorf("expected: %v\n got: %v", payment.ErrWrongSignature, err)
+	}
+
+	_, err = provider.VerifyWebhook(payload, "not hex")
+	if err != payment.ErrWrongSignature {
+		t.Errorf("expected: %v\n got: %v", payment.ErrWrongSignature, err)
+	}
+}
@@ -0,0 +1,26 @@
+package payment
+
+import (
+	"errors"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+var (
+	ErrUnknownIntent    = errors.New("unknown payment intent")
+	ErrWrongSignature   = errors.New("wrong webhook signature")
+	ErrIntentNotCapture = errors.New("payment intent can't be captured")
+	ErrWrongRefund      = errors.New("payment can't be refunded")
+)
+
+// Provider is a payment service the orders are paid through.
+// Intents are created for the order price, captured right after the order is
+// placed and confirmed either by the capture result or later by a webhook.
+//
+//go:generate mockgen -destination=./provider_mock.go -package=payment github.com/friends/internal/pkg/payment Provider
+type Provider interface {
+	CreateIntent(orderID int, amount int) (models.PaymentIntent, error)
+	Capture(intentID string) (models.PaymentIntent, error)
+	Refund(intentID string, amount int) (models.PaymentIntent, error)
+	VerifyWebhook(payload []byte, signature string) (models.PaymentEvent, error)
+}
@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/middleware"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/refund"
+	ownErr "github.com/friends/pkg/error"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+var partnerID = "2"
+var vendorID = "5"
+var orderID = "10"
+
+var testRequest = models.RefundRequest{
+	Reason: "missing",
+	Items:  []models.RefundItem{{ItemID: 1, Quantity: 1}},
+}
+
+var testRefunds = []models.Refund{
+	{ID: 1, OrderID: 10, ItemID: 1, Quantity: 1, Amount: 400, Reason: "missing", InitiatorID: partnerID},
+}
+
+var clientError = ownErr.NewClientError(fmt.Errorf("nothing to refund"))
+
+func TestRefundOrderSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefundUsecase := refund.NewMockUsecase(ctrl)
+
+	handler := New(mockRefundUsecase)
+
+	mockRefundUsecase.EXPECT().RefundOrder(partnerID, vendorID, orderID, testRequest).Times(1).Return(testRefunds, nil)
+
+	requestJson, _ := json.Marshal(&testRequest)
+	body := bytes.NewReader(requestJson)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/vendors/5/orders/10/refunds", body)
+	r = mux.SetURLVars(r, map[string]string{"vendorID": vendorID, "id": orderID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.RefundOrder(w, r.WithContext(ctx))
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+
+	var got []models.Refund
+	_ = json.NewDecoder(w.Body).Decode(&got)
+
+	if !reflect.DeepEqual(testRefunds, got) {
+		t.Errorf("expected: %v\n got: %v", testRefunds, got)
+	}
+}
+
+func TestRefundOrderError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefundUsecase := refund.NewMockUsecase(ctrl)
+
+	handler := New(mockRefundUsecase)
+
+	mockRefundUsecase.EXPECT().RefundOrder(partnerID, vendorID, orderID, testRequest).Times(1).Return(nil, clientError)
+
+	requestJson, _ := json.Marshal(&testRequest)
+	body := bytes.NewReader(requestJson)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/vendors/5/orders/10/refunds", body)
+	r = mux.SetURLVars(r, map[string]string{"vendorID": vendorID, "id": orderID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.RefundOrder(w, r.WithContext(ctx))
+
+	expected := http.StatusConflict
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestRefundOrderBadJson(t *testing.T) {
+	handler := RefundDelivery{}
+
+	body := bytes.NewReader([]byte(`{"reason: 0`))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/vendors/5/orders/10/refunds", body)
+	r = mux.SetURLVars(r, map[string]string{"vendorID": vendorID, "id": orderID})
+	ctx := context.WithValue(r.Context(), middleware.UserID(configs.UserID), partnerID)
+
+	handler.RefundOrder(w, r.WithContext(ctx))
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestRefundOrderNoUser(t *testing.T) {
+	handler := RefundDelivery{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/vendors/5/orders/10/refunds", nil)
+
+	handler.RefundOrder(w, r)
+
+	expected := http.StatusInternalServerError
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/middleware"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/refund"
+	ownErr "github.com/friends/pkg/error"
+	log "github.com/friends/pkg/logger"
+	"github.com/gorilla/mux"
+)
+
+type RefundDelivery struct {
+	refundUsecase refund.Usecase
+}
+
+func New(refundUsecase refund.Usecase) RefundDelivery {
+	return RefundDelivery{
+		refundUsecase: refundUsecase,
+	}
+}
+
+func (rd RefundDelivery) RefundOrder(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	partnerID, ok := r.Context().Value(middleware.UserID(configs.UserID)).(string)
+	if !ok {
+		err = fmt.Errorf("couldn't get userID from context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	vendorID, ok := mux.Vars(r)["vendorID"]
+	if !ok {
+		err = fmt.Errorf("no vendor id in path")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	orderID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no order id in path")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	request := models.RefundRequest{}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	request.Sanitize()
+
+	refunds, err := rd.refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusConflict)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(refunds)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/friends/internal/pkg/refund (interfaces: Repository)
+
+// Package refund is a generated GoMock package.
+package refund
+
+import (
+	models "github.com/friends/internal/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRefunds mocks base method
+func (m *MockRepository) AddRefunds(arg0, arg1 int, arg2 []models.Refund) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRefunds", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRefunds indicates an expected call of AddRefunds
+func (mr *MockRepositoryMockRecorder) AddRefunds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRefunds", reflect.TypeOf((*MockRepository)(nil).AddRefunds), arg0, arg1, arg2)
+}
+
+// GetOrderRefunds mocks base method
+func (m *MockRepository) GetOrderRefunds(arg0 int) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefunds", arg0)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefunds indicates an expected call of GetOrderRefunds
+func (mr *MockRepositoryMockRecorder) GetOrderRefunds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockRepository)(nil).GetOrderRefunds), arg0)
+}
+
+// RemoveRefunds mocks base method
+func (m *MockRepository) RemoveRefunds(arg0 []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRefunds", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRefunds indicates an expected call of RemoveRefunds
+func (mr *MockRepositoryMockRecorder) RemoveRefunds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRefunds", reflect.TypeOf((*MockRepository)(nil).RemoveRefunds), arg0)
+}
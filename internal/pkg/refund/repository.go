@@ -0,0 +1,16 @@
+package refund
+
+import (
+	"fmt"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+var ErrRefundTooBig = fmt.Errorf("refund is more than left on order")
+
+//go:generate mockgen -destination=./repo_mock.go -package=refund github.com/friends/internal/pkg/refund Repository
+type Repository interface {
+	AddRefunds(orderID int, total int, refunds []models.Refund) ([]models.Refund, error)
+	RemoveRefunds(refundIDs []int) error
+	GetOrderRefunds(orderID int) ([]models.Refund, error)
+}
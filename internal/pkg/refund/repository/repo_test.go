@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/refund"
+	"github.com/lib/pq"
+)
+
+var (
+	fatalError = "an error '%s' was not expected when opening a stub database connection"
+
+	createdAt = time.Now()
+	refunds   = []models.Refund{
+		{
+			OrderID:      10,
+			Amount:       500,
+			Reason:       "rejected",
+			InitiatorID:  "2",
+			CreatedAt:    createdAt,
+			CreatedAtStr: createdAt.Format(configs.TimeFormat),
+		},
+		{
+			OrderID:      10,
+			ItemID:       3,
+			Quantity:     2,
+			Amount:       200,
+			Reason:       "no bread",
+			InitiatorID:  "2",
+			CreatedAt:    createdAt,
+			CreatedAtStr: createdAt.Format(configs.TimeFormat),
+		},
+	}
+
+	dbError = fmt.Errorf("db error")
+)
+
+func TestAddRefunds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	item := refunds[1]
+
+	// good query
+	mock.ExpectBegin()
+	mock.
+		ExpectQuery("FOR UPDATE").
+		WithArgs(10).
+		WillReturnRows(mock.NewRows([]string{"refunded"}).AddRow(300))
+	mock.
+		ExpectQuery("FROM products_in_order").
+		WithArgs(3, 10).
+		WillReturnRows(mock.NewRows([]string{"left"}).AddRow(2))
+	mock.
+		ExpectQuery("INSERT INTO refunds").
+		WithArgs(10, sql.NullInt64{Int64: 3, Valid: true}, item.Quantity, item.Amount, item.Reason, item.InitiatorID, item.CreatedAt).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	added, err := repo.AddRefunds(10, 1000, []models.Refund{item})
+
+	if len(added) != 1 || added[0].ID != 7 {
+		t.Errorf("expected refund with id 7\n got: %v", added)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// more than left on order
+	mock.ExpectBegin()
+	mock.
+		ExpectQuery("FOR UPDATE").
+		WithArgs(10).
+		WillReturnRows(mock.NewRows([]string{"refunded"}).AddRow(600))
+	mock.ExpectRollback()
+
+	_, err = repo.AddRefunds(10, 1000, []models.Refund{refunds[0]})
+
+	if !errors.Is(err, refund.ErrRefundTooBig) {
+		t.Errorf("expected: %v\n got: %v", refund.ErrRefundTooBig, err)
+	}
+
+	// item already refunded
+	mock.ExpectBegin()
+	mock.
+		ExpectQuery("FOR UPDATE").
+		WithArgs(10).
+		WillReturnRows(mock.NewRows([]string{"refunded"}).AddRow(0))
+	mock.
+		ExpectQuery("FROM products_in_order").
+		WithArgs(3, 10).
+		WillReturnRows(mock.NewRows([]string{"left"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err = repo.AddRefunds(10, 1000, []models.Refund{item})
+
+	if !errors.Is(err, refund.ErrRefundTooBig) {
+		t.Errorf("expected: %v\n got: %v", refund.ErrRefundTooBig, err)
+	}
+
+	// no order
+	mock.ExpectBegin()
+	mock.
+		ExpectQuery("FOR UPDATE").
+		WithArgs(10).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repo.AddRefunds(10, 1000, []models.Refund{refunds[0]})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// bad query
+	mock.ExpectBegin()
+	mock.
+		ExpectQuery("FOR UPDATE").
+		WithArgs(10).
+		WillReturnRows(mock.NewRows([]string{"refunded"}).AddRow(0))
+	mock.
+		ExpectQuery("INSERT INTO refunds").
+		WithArgs(10, sql.NullInt64{}, refunds[0].Quantity, refunds[0].Amount, refunds[0].Reason, refunds[0].InitiatorID, refunds[0].CreatedAt).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	_, err = repo.AddRefunds(10, 1000, []models.Refund{refunds[0]})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRemoveRefunds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	// good query
+	mock.
+		ExpectExec("DELETE FROM refunds").
+		WithArgs(pq.Array([]int{7, 8})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.RemoveRefunds([]int{7, 8})
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("DELETE FROM refunds").
+		WithArgs(pq.Array([]int{7, 8})).
+		WillReturnError(dbError)
+
+	err = repo.RemoveRefunds([]int{7, 8})
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
+
+func TestGetOrderRefunds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+
+	rows := mock.NewRows([]string{"id", "orderID", "itemID", "quantity", "amount", "reason", "initiatorID", "created_at"})
+	for _, refund := range refunds {
+		rows.AddRow(refund.ID, refund.OrderID, refund.ItemID, refund.Quantity, refund.Amount, refund.Reason, refund.InitiatorID, refund.CreatedAt)
+	}
+
+	// good query
+	mock.
+		ExpectQuery("SELECT id, orderID, COALESCE").
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	got, err := repo.GetOrderRefunds(10)
+
+	if !reflect.DeepEqual(refunds, got) {
+		t.Errorf("expected: %v\n got: %v", refunds, got)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT id, orderID, COALESCE").
+		WithArgs(10).
+		WillReturnError(dbError)
+
+	_, err = repo.GetOrderRefunds(10)
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
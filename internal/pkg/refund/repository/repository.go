@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/refund"
+	ownErr "github.com/friends/pkg/error"
+	"github.com/lib/pq"
+)
+
+type RefundRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) refund.Repository {
+	return RefundRepository{
+		db: db,
+	}
+}
+
+// AddRefunds saves the refunds of the order. The order is locked while
+// the refunds are checked against the order total and the items left,
+// so concurrent refunds can't return more than was paid.
+func (r RefundRepository) AddRefunds(orderID int, total int, refunds []models.Refund) ([]models.Refund, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	var refunded int
+	err = tx.QueryRow(
+		`SELECT (SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE orderID = orders.id)
+		FROM orders WHERE id = $1 FOR UPDATE`,
+		orderID,
+	).Scan(&refunded)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("couldn't lock order: %w", err)
+	}
+
+	amount := 0
+	for _, refund := range refunds {
+		amount += refund.Amount
+	}
+
+	if refunded+amount > total {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("%w: %v of %v is already refunded", refund.ErrRefundTooBig, refunded, total)
+	}
+
+	for i, refund := range refunds {
+		if refund.ItemID != 0 {
+			err = checkItemLeft(tx, orderID, refund)
+			if err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+
+		itemID := sql.NullInt64{Int64: int64(refund.ItemID), Valid: refund.ItemID != 0}
+
+		err = tx.QueryRow(
+			`INSERT INTO refunds (orderID, itemID, quantity, amount, reason, initiatorID, created_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+			orderID, itemID, refund.Quantity, refund.Amount, refund.Reason, refund.InitiatorID, refund.CreatedAt,
+		).Scan(&refunds[i].ID)
+
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("couldn't insert refund: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return refunds, nil
+}
+
+// checkItemLeft checks that the item wasn't refunded in the meantime.
+// Refunds inserted earlier in the transaction are counted too.
+func checkItemLeft(tx *sql.Tx, orderID int, item models.Refund) error {
+	var left int
+	err := tx.QueryRow(
+		`SELECT quantity - (SELECT COALESCE(SUM(quantity), 0) FROM refunds WHERE itemID = products_in_order.id)
+		FROM products_in_order WHERE id = $1 AND orderID = $2`,
+		item.ItemID, orderID,
+	).Scan(&left)
+
+	if err != nil {
+		return fmt.Errorf("couldn't get item %v left to refund: %w", item.ItemID, err)
+	}
+
+	if item.Quantity > left {
+		return fmt.Errorf("%w: only %v of item %v left", refund.ErrRefundTooBig, left, item.ItemID)
+	}
+
+	return nil
+}
+
+// RemoveRefunds deletes refunds the payment provider didn't accept.
+func (r RefundRepository) RemoveRefunds(refundIDs []int) error {
+	_, err := r.db.Exec("DELETE FROM refunds WHERE id = ANY ($1)", pq.Array(refundIDs))
+	if err != nil {
+		return fmt.Errorf("couldn't remove refunds: %w", err)
+	}
+
+	return nil
+}
+
+func (r RefundRepository) GetOrderRefunds(orderID int) ([]models.Refund, error) {
+	rows, err := r.db.Query(
+		`SELECT id, orderID, COALESCE(itemID, 0), quantity, amount, reason, initiatorID, created_at
+		FROM refunds WHERE orderID = $1 ORDER BY created_at, id`,
+		orderID,
+	)
+
+	if err != nil {
+		return nil, ownErr.NewServerError(fmt.Errorf("couldn't get refunds from db: %w", err))
+	}
+	defer rows.Close()
+
+	refunds := make([]models.Refund, 0)
+	for rows.Next() {
+		var refund models.Refund
+		err = rows.Scan(
+			&refund.ID, &refund.OrderID, &refund.ItemID, &refund.Quantity,
+			&refund.Amount, &refund.Reason, &refund.InitiatorID, &refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, ownErr.NewServerError(fmt.Errorf("couldn't get refund from db: %w", err))
+		}
+		refund.CreatedAtStr = refund.CreatedAt.Format(configs.TimeFormat)
+
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, nil
+}
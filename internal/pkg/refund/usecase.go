@@ -0,0 +1,10 @@
+package refund
+
+import "github.com/friends/internal/pkg/models"
+
+//go:generate mockgen -destination=./usecase_mock.go -package=refund github.com/friends/internal/pkg/refund Usecase
+type Usecase interface {
+	RefundOrder(partnerID string, vendorID string, orderID string, request models.RefundRequest) ([]models.Refund, error)
+	RefundWholeOrder(orderID string, initiatorID string, reason string) error
+	GetOrderRefunds(orderID int) ([]models.Refund, error)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/order"
+	"github.com/friends/internal/pkg/payment"
+	"github.com/friends/internal/pkg/refund"
+	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
+)
+
+type RefundUsecase struct {
+	refundRepository refund.Repository
+	orderRepository  order.Repository
+	vendorRepository vendors.Repository
+	paymentProvider  payment.Provider
+}
+
+func New(
+	refundRepository refund.Repository, orderRepository order.Repository,
+	vendorRepository vendors.Repository, paymentProvider payment.Provider,
+) refund.Usecase {
+	return RefundUsecase{
+		refundRepository: refundRepository,
+		orderRepository:  orderRepository,
+		vendorRepository: vendorRepository,
+		paymentProvider:  paymentProvider,
+	}
+}
+
+// RefundOrder returns money for the listed line items of the order
+// or for the rest of the order when no items are given.
+func (r RefundUsecase) RefundOrder(
+	partnerID string, vendorID string, orderID string, request models.RefundRequest,
+) ([]models.Refund, error) {
+	err := r.vendorRepository.CheckVendorOwner(partnerID, vendorID)
+	if err != nil {
+		return nil, ownErr.NewClientError(fmt.Errorf("user is not vendor partner: %w", err))
+	}
+
+	orderInfo, err := r.orderRepository.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strconv.Itoa(orderInfo.VendorID) != vendorID {
+		return nil, ownErr.NewClientError(fmt.Errorf("order doesn't belong to the vendor"))
+	}
+
+	refunds, err := buildRefunds(orderInfo, request, partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.refund(orderInfo, refunds)
+}
+
+// RefundWholeOrder returns the money left on a paid order. It does nothing
+// for orders that were not paid or are already fully refunded.
+func (r RefundUsecase) RefundWholeOrder(orderID string, initiatorID string, reason string) error {
+	orderInfo, err := r.orderRepository.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	if orderInfo.PaymentStatus != models.PaymentStatusPaid {
+		return nil
+	}
+
+	refunds, err := buildRefunds(orderInfo, models.RefundRequest{Reason: reason}, initiatorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.refund(orderInfo, refunds)
+	return err
+}
+
+func (r RefundUsecase) GetOrderRefunds(orderID int) ([]models.Refund, error) {
+	return r.refundRepository.GetOrderRefunds(orderID)
+}
+
+// refund saves the refunds first, so a concurrent refund of the same order
+// sees them, and then returns the money. Refunds the provider didn't
+// accept are removed.
+func (r RefundUsecase) refund(orderInfo models.OrderResponse, refunds []models.Refund) ([]models.Refund, error) {
+	refunds, err := r.refundRepository.AddRefunds(orderInfo.ID, orderInfo.Total(), refunds)
+	if errors.Is(err, refund.ErrRefundTooBig) {
+		return nil, ownErr.NewClientError(err)
+	}
+
+	if err != nil {
+		return nil, ownErr.NewServerError(err)
+	}
+
+	amount := 0
+	ids := make([]int, 0, len(refunds))
+	for _, added := range refunds {
+		amount += added.Amount
+		ids = append(ids, added.ID)
+	}
+
+	intent, err := r.paymentProvider.Refund(orderInfo.PaymentIntent, amount)
+	if err != nil {
+		return nil, r.removeRefunds(ids, err)
+	}
+
+	_, err = r.orderRepository.UpdatePaymentStatus(orderInfo.PaymentIntent, models.PaymentStatusPaid, intent.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+func (r RefundUsecase) removeRefunds(ids []int, refundErr error) error {
+	err := r.refundRepository.RemoveRefunds(ids)
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't refund payment: %v: %w", refundErr, err))
+	}
+
+	if errors.Is(refundErr, payment.ErrWrongRefund) || errors.Is(refundErr, payment.ErrUnknownIntent) {
+		return ownErr.NewClientError(fmt.Errorf("couldn't refund payment: %w", refundErr))
+	}
+
+	return ownErr.NewServerError(fmt.Errorf("couldn't refund payment: %w", refundErr))
+}
+
+// buildRefunds checks the request against the order and what was already
+// refunded and computes the amount of every refund.
+func buildRefunds(
+	orderInfo models.OrderResponse, request models.RefundRequest, initiatorID string,
+) ([]models.Refund, error) {
+	if orderInfo.PaymentStatus != models.PaymentStatusPaid {
+		return nil, ownErr.NewClientError(fmt.Errorf("order payment is %q, nothing to refund", orderInfo.PaymentStatus))
+	}
+
+	left := orderInfo.Total() - orderInfo.RefundedAmount
+	now := time.Now()
+
+	newRefund := func(itemID, quantity, amount int) models.Refund {
+		return models.Refund{
+			OrderID:      orderInfo.ID,
+			ItemID:       itemID,
+			Quantity:     quantity,
+			Amount:       amount,
+			Reason:       request.Reason,
+			InitiatorID:  initiatorID,
+			CreatedAt:    now,
+			CreatedAtStr: now.Format(configs.TimeFormat),
+		}
+	}
+
+	if len(request.Items) == 0 {
+		if left <= 0 {
+			return nil, ownErr.NewClientError(fmt.Errorf("order is already refunded"))
+		}
+
+		return []models.Refund{newRefund(0, 0, left)}, nil
+	}
+
+	items := make(map[int]models.OrderProduct, len(orderInfo.Products))
+	for _, product := range orderInfo.Products {
+		items[product.ID] = product
+	}
+
+	refunds := make([]models.Refund, 0, len(request.Items))
+	amount := 0
+	for _, requested := range request.Items {
+		item, ok := items[requested.ItemID]
+		if !ok {
+			return nil, ownErr.NewClientError(fmt.Errorf("no item %v in order", requested.ItemID))
+		}
+
+		if requested.Quantity <= 0 || requested.Quantity > item.Quantity-item.Refunded {
+			return nil, ownErr.NewClientError(
+				fmt.Errorf("wrong quantity %v to refund for item %v", requested.Quantity, requested.ItemID),
+			)
+		}
+
+		// the same item may be listed twice, keep the rest of it up to date
+		item.Refunded += requested.Quantity
+		items[requested.ItemID] = item
+
+		refunds = append(refunds, newRefund(item.ID, requested.Quantity, item.Price*requested.Quantity))
+		amount += item.Price * requested.Quantity
+	}
+
+	if amount > left {
+		return nil, ownErr.NewClientError(fmt.Errorf("refund amount %v is more than %v left on order", amount, left))
+	}
+
+	return refunds, nil
+}
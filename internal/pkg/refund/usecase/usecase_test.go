@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/friends/internal/pkg/models"
+	"github.com/friends/internal/pkg/order"
+	"github.com/friends/internal/pkg/payment"
+	"github.com/friends/internal/pkg/refund"
+	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	orderID   = "10"
+	partnerID = "2"
+	vendorID  = "5"
+
+	paidOrder = models.OrderResponse{
+		ID:             10,
+		VendorID:       5,
+		Price:          1000,
+		RefundedAmount: 300,
+		PaymentStatus:  models.PaymentStatusPaid,
+		PaymentIntent:  "pi_10",
+		Products: []models.OrderProduct{
+			{ID: 1, Name: "borscht", Price: 400, Quantity: 1},
+			{ID: 2, Name: "bread", Price: 300, Quantity: 2, Refunded: 1},
+		},
+	}
+
+	dbError = fmt.Errorf("db error")
+)
+
+func isClientError(err error) bool {
+	reqErr, ok := err.(ownErr.RequestError)
+	return ok && reqErr.IsClientError()
+}
+
+func TestRefundOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefundRepo := refund.NewMockRepository(ctrl)
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockVendorRepo := vendors.NewMockRepository(ctrl)
+	mockProvider := payment.NewMockProvider(ctrl)
+	refundUsecase := New(mockRefundRepo, mockOrderRepo, mockVendorRepo, mockProvider)
+
+	// line item refund
+	request := models.RefundRequest{
+		Reason: "missing",
+		Items:  []models.RefundItem{{ItemID: 1, Quantity: 1}},
+	}
+
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).DoAndReturn(
+		func(orderID int, total int, refunds []models.Refund) ([]models.Refund, error) {
+			if len(refunds) != 1 || refunds[0].ItemID != 1 || refunds[0].Amount != 400 || refunds[0].InitiatorID != partnerID {
+				t.Errorf("unexpected refunds: %v", refunds)
+			}
+			return refunds, nil
+		},
+	)
+	mockProvider.EXPECT().Refund("pi_10", 400).Times(1).Return(models.PaymentIntent{Status: models.PaymentStatusPaid}, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_10", models.PaymentStatusPaid, models.PaymentStatusPaid).Times(1).Return(true, nil)
+
+	_, err := refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// rest of the order
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).DoAndReturn(
+		func(orderID int, total int, refunds []models.Refund) ([]models.Refund, error) {
+			return refunds, nil
+		},
+	)
+	mockProvider.EXPECT().Refund("pi_10", 700).Times(1).Return(models.PaymentIntent{Status: models.PaymentStatusRefunded}, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_10", models.PaymentStatusPaid, models.PaymentStatusRefunded).Times(1).Return(true, nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, models.RefundRequest{Reason: "sorry"})
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// more items than left
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, models.RefundRequest{
+		Items: []models.RefundItem{{ItemID: 2, Quantity: 1}, {ItemID: 2, Quantity: 1}},
+	})
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// unknown item
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, models.RefundRequest{
+		Items: []models.RefundItem{{ItemID: 3, Quantity: 1}},
+	})
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// order of another vendor
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, "6").Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, "6", orderID, request)
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// not paid order
+	unpaid := paidOrder
+	unpaid.PaymentStatus = models.PaymentStatusPending
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(unpaid, nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// not partner
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(dbError)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// refunded concurrently
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).
+		Return(nil, fmt.Errorf("%w: only 0 of item 1 left", refund.ErrRefundTooBig))
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// provider declined
+	added := []models.Refund{{ID: 7, ItemID: 1, Quantity: 1, Amount: 400}}
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).Return(added, nil)
+	mockProvider.EXPECT().Refund("pi_10", 400).Times(1).Return(models.PaymentIntent{}, payment.ErrWrongRefund)
+	mockRefundRepo.EXPECT().RemoveRefunds([]int{7}).Times(1).Return(nil)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if !isClientError(err) {
+		t.Errorf("expected client error. Got: %v", err)
+	}
+
+	// provider unavailable and refunds not removed
+	mockVendorRepo.EXPECT().CheckVendorOwner(partnerID, vendorID).Times(1).Return(nil)
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).Return(added, nil)
+	mockProvider.EXPECT().Refund("pi_10", 400).Times(1).Return(models.PaymentIntent{}, fmt.Errorf("unavailable"))
+	mockRefundRepo.EXPECT().RemoveRefunds([]int{7}).Times(1).Return(dbError)
+
+	_, err = refundUsecase.RefundOrder(partnerID, vendorID, orderID, request)
+
+	if err == nil || isClientError(err) {
+		t.Errorf("expected server error. Got: %v", err)
+	}
+}
+
+func TestRefundWholeOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefundRepo := refund.NewMockRepository(ctrl)
+	mockOrderRepo := order.NewMockRepository(ctrl)
+	mockProvider := payment.NewMockProvider(ctrl)
+	refundUsecase := New(mockRefundRepo, mockOrderRepo, nil, mockProvider)
+
+	// paid order
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).DoAndReturn(
+		func(orderID int, total int, refunds []models.Refund) ([]models.Refund, error) {
+			return refunds, nil
+		},
+	)
+	mockProvider.EXPECT().Refund("pi_10", 700).Times(1).Return(models.PaymentIntent{Status: models.PaymentStatusRefunded}, nil)
+	mockOrderRepo.EXPECT().UpdatePaymentStatus("pi_10", models.PaymentStatusPaid, models.PaymentStatusRefunded).Times(1).Return(true, nil)
+
+	err := refundUsecase.RefundWholeOrder(orderID, partnerID, "rejected")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// not paid order
+	unpaid := paidOrder
+	unpaid.PaymentStatus = models.PaymentStatusFailed
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(unpaid, nil)
+
+	err = refundUsecase.RefundWholeOrder(orderID, partnerID, "rejected")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// db error
+	mockOrderRepo.EXPECT().GetOrder(orderID).Times(1).Return(paidOrder, nil)
+	mockRefundRepo.EXPECT().AddRefunds(10, 1000, gomock.Any()).Times(1).Return(nil, dbError)
+
+	err = refundUsecase.RefundWholeOrder(orderID, partnerID, "rejected")
+
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}
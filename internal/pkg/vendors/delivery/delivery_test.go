@@ -12,6 +12,7 @@ import (
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 )
@@ -194,7 +195,7 @@ func TestGetNearestSuccess(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 	handler := NewVendorDelivery(mockVendorUsecase)
 
-	mockVendorUsecase.EXPECT().GetNearest(gomock.Any(), gomock.Any()).Times(1).Return(testVendors, nil)
+	mockVendorUsecase.EXPECT().GetNearest(gomock.Any(), gomock.Any(), configs.NearestLimit, 0).Times(1).Return(testVendors, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/vendors", nil)
@@ -224,7 +225,7 @@ func TestGetNearestError(t *testing.T) {
 	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
 	handler := NewVendorDelivery(mockVendorUsecase)
 
-	mockVendorUsecase.EXPECT().GetNearest(gomock.Any(), gomock.Any()).Times(1).Return(nil, dbError)
+	mockVendorUsecase.EXPECT().GetNearest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, dbError)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/vendors", nil)
@@ -277,6 +278,25 @@ func TestGetNearestWrongQuery2(t *testing.T) {
 	}
 }
 
+func TestGetNearestWrongPaging(t *testing.T) {
+	handler := VendorDelivery{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors", nil)
+	q := r.URL.Query()
+	q.Add(configs.Longitude, Longitude)
+	q.Add(configs.Latitude, Latitude)
+	q.Add(configs.Limit, "0")
+	r.URL.RawQuery = q.Encode()
+
+	handler.GetNearest(w, r)
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
 func TestGetNearestNoQuery(t *testing.T) {
 	handler := VendorDelivery{}
 
@@ -308,3 +328,139 @@ func TestGetNearestNoQuery2(t *testing.T) {
 		t.Errorf("expected: %v\n got: %v", expected, w.Code)
 	}
 }
+
+func TestGetDeliverySettingsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+	handler := NewVendorDelivery(mockVendorUsecase)
+
+	settings := models.DeliverySettings{
+		MinOrderAmount: 500,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 2000, Fee: 50}},
+	}
+
+	mockVendorUsecase.EXPECT().GetDeliverySettings(1).Times(1).Return(settings, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors/1/delivery", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+	handler.GetDeliverySettings(w, r)
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+
+	var respSettings models.DeliverySettings
+	_ = json.Unmarshal(w.Body.Bytes(), &respSettings)
+	if !reflect.DeepEqual(settings, respSettings) {
+		t.Errorf("expected: %v\n got: %v", settings, respSettings)
+	}
+}
+
+func TestGetDeliverySettingsBadID(t *testing.T) {
+	handler := VendorDelivery{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors/a/delivery", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "a"})
+
+	handler.GetDeliverySettings(w, r)
+
+	expected := http.StatusBadRequest
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestGetDeliveryZoneSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+	handler := NewVendorDelivery(mockVendorUsecase)
+
+	zone := models.DeliveryZone{
+		Type:        models.GeoJSONMultiPolygon,
+		Coordinates: [][][][2]float64{{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.7}}}},
+	}
+
+	mockVendorUsecase.EXPECT().GetDeliveryZone(1).Times(1).Return(zone, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors/1/zone", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+	handler.GetDeliveryZone(w, r)
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+
+	var respZone models.DeliveryZone
+	_ = json.Unmarshal(w.Body.Bytes(), &respZone)
+	if !reflect.DeepEqual(zone, respZone) {
+		t.Errorf("expected: %v\n got: %v", zone, respZone)
+	}
+}
+
+func TestGetDeliveryZoneNoVendor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+	handler := NewVendorDelivery(mockVendorUsecase)
+
+	mockVendorUsecase.EXPECT().GetDeliveryZone(1).Times(1).Return(
+		models.DeliveryZone{}, ownErr.NewClientError(fmt.Errorf("no such vendor")),
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors/1/zone", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+	handler.GetDeliveryZone(w, r)
+
+	expected := http.StatusNotFound
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+}
+
+func TestGetScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVendorUsecase := vendors.NewMockUsecase(ctrl)
+	handler := NewVendorDelivery(mockVendorUsecase)
+
+	schedule := models.Schedule{
+		TimeZone:  "Europe/Moscow",
+		Hours:     []models.OpeningHours{{Weekday: 1, Opens: "10:00", Closes: "22:00"}},
+		Holidays:  []string{"2021-01-01"},
+		IsOpenNow: true,
+	}
+
+	mockVendorUsecase.EXPECT().GetSchedule(1).Times(1).Return(schedule, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/vendors/1/schedule", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+	handler.GetSchedule(w, r)
+
+	expected := http.StatusOK
+	if w.Code != expected {
+		t.Errorf("expected: %v\n got: %v", expected, w.Code)
+	}
+
+	var respSchedule models.Schedule
+	_ = json.Unmarshal(w.Body.Bytes(), &respSchedule)
+	if !reflect.DeepEqual(schedule, respSchedule) {
+		t.Errorf("expected: %v\n got: %v", schedule, respSchedule)
+	}
+}
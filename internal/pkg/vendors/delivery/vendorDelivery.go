@@ -8,6 +8,7 @@ import (
 
 	"github.com/friends/configs"
 	"github.com/friends/internal/pkg/vendors"
+	ownErr "github.com/friends/pkg/error"
 	log "github.com/friends/pkg/logger"
 	"github.com/gorilla/mux"
 )
@@ -110,7 +111,13 @@ func (v VendorDelivery) GetNearest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vendors, err := v.vendorUsecase.GetNearest(longitude, latitude)
+	limit, offset, err := parsePaging(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vendors, err := v.vendorUsecase.GetNearest(longitude, latitude, limit, offset)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -187,3 +194,127 @@ func (v VendorDelivery) GetAllCategories(w http.ResponseWriter, r *http.Request)
 		return
 	}
 }
+
+func (v VendorDelivery) GetDeliverySettings(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	strID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no id in url")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	settings, err := v.vendorUsecase.GetDeliverySettings(id)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusNotFound)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (v VendorDelivery) GetDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	strID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no id in url")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	zone, err := v.vendorUsecase.GetDeliveryZone(id)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusNotFound)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(zone)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (v VendorDelivery) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ErrorLogWithCtx(r.Context(), err)
+		}
+	}()
+
+	strID, ok := mux.Vars(r)["id"]
+	if !ok {
+		err = fmt.Errorf("no id in url")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := v.vendorUsecase.GetSchedule(id)
+	if err != nil {
+		ownErr.HandleErrorAndWriteResponse(w, err, http.StatusNotFound)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(schedule)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func parsePaging(r *http.Request) (limit, offset int, err error) {
+	limit = configs.NearestLimit
+	if limitQueryParam, ok := r.URL.Query()[configs.Limit]; ok {
+		limit, err = strconv.Atoi(limitQueryParam[0])
+		if err != nil {
+			return 0, 0, err
+		}
+		if limit <= 0 || limit > configs.MaxNearestLimit {
+			return 0, 0, fmt.Errorf("wrong limit: %v", limit)
+		}
+	}
+
+	if offsetQueryParam, ok := r.URL.Query()[configs.Offset]; ok {
+		offset, err = strconv.Atoi(offsetQueryParam[0])
+		if err != nil {
+			return 0, 0, err
+		}
+		if offset < 0 {
+			return 0, 0, fmt.Errorf("wrong offset: %v", offset)
+		}
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,59 @@
+package vendors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+var (
+	ErrTooFar           = errors.New("delivery address is too far from the vendor")
+	ErrOutsideArea      = errors.New("delivery address is outside the vendor service area")
+	ErrBelowMinOrder    = errors.New("order amount is below the vendor minimum")
+	ErrWrongFeeSettings = errors.New("wrong delivery fee settings")
+)
+
+// DeliveryFee picks the fee of the nearest tier covering the distance.
+// Vendors without tiers deliver for free.
+func DeliveryFee(tiers []models.DeliveryFeeTier, distance int) (int, error) {
+	if len(tiers) == 0 {
+		return 0, nil
+	}
+
+	sorted := make([]models.DeliveryFeeTier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MaxDistance < sorted[j].MaxDistance
+	})
+
+	for _, tier := range sorted {
+		if distance <= tier.MaxDistance {
+			return tier.Fee, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v m", ErrTooFar, distance)
+}
+
+// CheckDeliverySettings validates the settings configured by a partner.
+func CheckDeliverySettings(settings models.DeliverySettings) error {
+	if settings.MinOrderAmount < 0 {
+		return fmt.Errorf("%w: negative minimum order amount", ErrWrongFeeSettings)
+	}
+
+	distances := make(map[int]bool, len(settings.FeeTiers))
+	for _, tier := range settings.FeeTiers {
+		if tier.MaxDistance <= 0 || tier.Fee < 0 {
+			return fmt.Errorf("%w: tier %v m costs %v", ErrWrongFeeSettings, tier.MaxDistance, tier.Fee)
+		}
+
+		if distances[tier.MaxDistance] {
+			return fmt.Errorf("%w: duplicate tier %v m", ErrWrongFeeSettings, tier.MaxDistance)
+		}
+		distances[tier.MaxDistance] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package vendors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+func TestDeliveryFee(t *testing.T) {
+	tiers := []models.DeliveryFeeTier{
+		{MaxDistance: 5000, Fee: 150},
+		{MaxDistance: 1000, Fee: 0},
+		{MaxDistance: 3000, Fee: 90},
+	}
+
+	tests := []struct {
+		distance int
+		fee      int
+		err      error
+	}{
+		{distance: 0, fee: 0},
+		{distance: 1000, fee: 0},
+		{distance: 1001, fee: 90},
+		{distance: 4999, fee: 150},
+		{distance: 5001, err: ErrTooFar},
+	}
+
+	for _, test := range tests {
+		fee, err := DeliveryFee(tiers, test.distance)
+
+		if fee != test.fee {
+			t.Errorf("distance %v. expected: %v\n got: %v", test.distance, test.fee, fee)
+		}
+
+		if !errors.Is(err, test.err) {
+			t.Errorf("distance %v. expected: %v\n got: %v", test.distance, test.err, err)
+		}
+	}
+
+	fee, err := DeliveryFee(nil, 100000)
+	if fee != 0 || err != nil {
+		t.Errorf("expected free delivery without tiers. Got: %v, %v", fee, err)
+	}
+}
+
+func TestCheckDeliverySettings(t *testing.T) {
+	good := models.DeliverySettings{
+		MinOrderAmount: 300,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 1000, Fee: 0}, {MaxDistance: 3000, Fee: 90}},
+	}
+
+	if err := CheckDeliverySettings(good); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	bad := []models.DeliverySettings{
+		{MinOrderAmount: -1},
+		{FeeTiers: []models.DeliveryFeeTier{{MaxDistance: 0, Fee: 10}}},
+		{FeeTiers: []models.DeliveryFeeTier{{MaxDistance: 100, Fee: -10}}},
+		{FeeTiers: []models.DeliveryFeeTier{{MaxDistance: 100, Fee: 10}, {MaxDistance: 100, Fee: 20}}},
+	}
+
+	for _, settings := range bad {
+		if err := CheckDeliverySettings(settings); !errors.Is(err, ErrWrongFeeSettings) {
+			t.Errorf("expected: %v\n got: %v", ErrWrongFeeSettings, err)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/friends/internal/pkg/vendors (interfaces: Repository)
+
+// Package vendors is a generated GoMock package.
+package vendors
+
+import (
+	models "github.com/friends/internal/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddProduct mocks base method
+func (m *MockRepository) AddProduct(arg0 models.Product) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProduct", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProduct indicates an expected call of AddProduct
+func (mr *MockRepositoryMockRecorder) AddProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockRepository)(nil).AddProduct), arg0)
+}
+
+// CheckVendorOwner mocks base method
+func (m *MockRepository) CheckVendorOwner(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckVendorOwner", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckVendorOwner indicates an expected call of CheckVendorOwner
+func (mr *MockRepositoryMockRecorder) CheckVendorOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckVendorOwner", reflect.TypeOf((*MockRepository)(nil).CheckVendorOwner), arg0, arg1)
+}
+
+// Create mocks base method
+func (m *MockRepository) Create(arg0 string, arg1 models.Vendor) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteProduct mocks base method
+func (m *MockRepository) DeleteProduct(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct
+func (mr *MockRepositoryMockRecorder) DeleteProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockRepository)(nil).DeleteProduct), arg0)
+}
+
+// Get mocks base method
+func (m *MockRepository) Get(arg0 int) (models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockRepositoryMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), arg0)
+}
+
+// Get3RandomVendors mocks base method
+func (m *MockRepository) Get3RandomVendors() ([]models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get3RandomVendors")
+	ret0, _ := ret[0].([]models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get3RandomVendors indicates an expected call of Get3RandomVendors
+func (mr *MockRepositoryMockRecorder) Get3RandomVendors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get3RandomVendors", reflect.TypeOf((*MockRepository)(nil).Get3RandomVendors))
+}
+
+// GetAll mocks base method
+func (m *MockRepository) GetAll() ([]models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll))
+}
+
+// GetAllCategories mocks base method
+func (m *MockRepository) GetAllCategories() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories
+func (mr *MockRepositoryMockRecorder) GetAllCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockRepository)(nil).GetAllCategories))
+}
+
+// GetAllProductsWithIDsFromSameVendor mocks base method
+func (m *MockRepository) GetAllProductsWithIDsFromSameVendor(arg0 []int) ([]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProductsWithIDsFromSameVendor", arg0)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProductsWithIDsFromSameVendor indicates an expected call of GetAllProductsWithIDsFromSameVendor
+func (mr *MockRepositoryMockRecorder) GetAllProductsWithIDsFromSameVendor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProductsWithIDsFromSameVendor", reflect.TypeOf((*MockRepository)(nil).GetAllProductsWithIDsFromSameVendor), arg0)
+}
+
+// GetDeliverySettings mocks base method
+func (m *MockRepository) GetDeliverySettings(arg0 int) (models.DeliverySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliverySettings", arg0)
+	ret0, _ := ret[0].(models.DeliverySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliverySettings indicates an expected call of GetDeliverySettings
+func (mr *MockRepositoryMockRecorder) GetDeliverySettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliverySettings", reflect.TypeOf((*MockRepository)(nil).GetDeliverySettings), arg0)
+}
+
+// GetDeliveryZone mocks base method
+func (m *MockRepository) GetDeliveryZone(arg0 int) (models.DeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryZone", arg0)
+	ret0, _ := ret[0].(models.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryZone indicates an expected call of GetDeliveryZone
+func (mr *MockRepositoryMockRecorder) GetDeliveryZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryZone", reflect.TypeOf((*MockRepository)(nil).GetDeliveryZone), arg0)
+}
+
+// GetDistance mocks base method
+func (m *MockRepository) GetDistance(arg0 int, arg1, arg2 float64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDistance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDistance indicates an expected call of GetDistance
+func (mr *MockRepositoryMockRecorder) GetDistance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistance", reflect.TypeOf((*MockRepository)(nil).GetDistance), arg0, arg1, arg2)
+}
+
+// GetNearest mocks base method
+func (m *MockRepository) GetNearest(arg0, arg1 float64, arg2, arg3 int) ([]models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearest indicates an expected call of GetNearest
+func (mr *MockRepositoryMockRecorder) GetNearest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearest", reflect.TypeOf((*MockRepository)(nil).GetNearest), arg0, arg1, arg2, arg3)
+}
+
+// GetPartnerShops mocks base method
+func (m *MockRepository) GetPartnerShops(arg0 string) ([]models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPartnerShops", arg0)
+	ret0, _ := ret[0].([]models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartnerShops indicates an expected call of GetPartnerShops
+func (mr *MockRepositoryMockRecorder) GetPartnerShops(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerShops", reflect.TypeOf((*MockRepository)(nil).GetPartnerShops), arg0)
+}
+
+// GetSchedules mocks base method
+func (m *MockRepository) GetSchedules(arg0 []int) (map[int]models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", arg0)
+	ret0, _ := ret[0].(map[int]models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules
+func (mr *MockRepositoryMockRecorder) GetSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockRepository)(nil).GetSchedules), arg0)
+}
+
+// GetSimilar mocks base method
+func (m *MockRepository) GetSimilar(arg0 string, arg1, arg2 float64) ([]models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilar", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilar indicates an expected call of GetSimilar
+func (mr *MockRepositoryMockRecorder) GetSimilar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilar", reflect.TypeOf((*MockRepository)(nil).GetSimilar), arg0, arg1, arg2)
+}
+
+// GetVendorFromProduct mocks base method
+func (m *MockRepository) GetVendorFromProduct(arg0 int) (models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorFromProduct", arg0)
+	ret0, _ := ret[0].(models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorFromProduct indicates an expected call of GetVendorFromProduct
+func (mr *MockRepositoryMockRecorder) GetVendorFromProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorFromProduct", reflect.TypeOf((*MockRepository)(nil).GetVendorFromProduct), arg0)
+}
+
+// GetVendorIDFromProduct mocks base method
+func (m *MockRepository) GetVendorIDFromProduct(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorIDFromProduct", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorIDFromProduct indicates an expected call of GetVendorIDFromProduct
+func (mr *MockRepositoryMockRecorder) GetVendorIDFromProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorIDFromProduct", reflect.TypeOf((*MockRepository)(nil).GetVendorIDFromProduct), arg0)
+}
+
+// GetVendorInfo mocks base method
+func (m *MockRepository) GetVendorInfo(arg0 string) (models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorInfo", arg0)
+	ret0, _ := ret[0].(models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorInfo indicates an expected call of GetVendorInfo
+func (mr *MockRepositoryMockRecorder) GetVendorInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorInfo", reflect.TypeOf((*MockRepository)(nil).GetVendorInfo), arg0)
+}
+
+// GetVendorOwner mocks base method
+func (m *MockRepository) GetVendorOwner(arg0 int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorOwner", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorOwner indicates an expected call of GetVendorOwner
+func (mr *MockRepositoryMockRecorder) GetVendorOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorOwner", reflect.TypeOf((*MockRepository)(nil).GetVendorOwner), arg0)
+}
+
+// IsInServiceArea mocks base method
+func (m *MockRepository) IsInServiceArea(arg0 int, arg1, arg2 float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInServiceArea", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInServiceArea indicates an expected call of IsInServiceArea
+func (mr *MockRepositoryMockRecorder) IsInServiceArea(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInServiceArea", reflect.TypeOf((*MockRepository)(nil).IsInServiceArea), arg0, arg1, arg2)
+}
+
+// IsVendorExists mocks base method
+func (m *MockRepository) IsVendorExists(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsVendorExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsVendorExists indicates an expected call of IsVendorExists
+func (mr *MockRepositoryMockRecorder) IsVendorExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVendorExists", reflect.TypeOf((*MockRepository)(nil).IsVendorExists), arg0)
+}
+
+// SetPaused mocks base method
+func (m *MockRepository) SetPaused(arg0 int, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaused indicates an expected call of SetPaused
+func (mr *MockRepositoryMockRecorder) SetPaused(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockRepository)(nil).SetPaused), arg0, arg1)
+}
+
+// Update mocks base method
+func (m *MockRepository) Update(arg0 models.Vendor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), arg0)
+}
+
+// UpdateDeliverySettings mocks base method
+func (m *MockRepository) UpdateDeliverySettings(arg0 int, arg1 models.DeliverySettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliverySettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeliverySettings indicates an expected call of UpdateDeliverySettings
+func (mr *MockRepositoryMockRecorder) UpdateDeliverySettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliverySettings", reflect.TypeOf((*MockRepository)(nil).UpdateDeliverySettings), arg0, arg1)
+}
+
+// UpdateDeliveryZone mocks base method
+func (m *MockRepository) UpdateDeliveryZone(arg0 int, arg1 models.DeliveryZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliveryZone", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeliveryZone indicates an expected call of UpdateDeliveryZone
+func (mr *MockRepositoryMockRecorder) UpdateDeliveryZone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliveryZone", reflect.TypeOf((*MockRepository)(nil).UpdateDeliveryZone), arg0, arg1)
+}
+
+// UpdateProduct mocks base method
+func (m *MockRepository) UpdateProduct(arg0 models.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct
+func (mr *MockRepositoryMockRecorder) UpdateProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockRepository)(nil).UpdateProduct), arg0)
+}
+
+// UpdateProductImage mocks base method
+func (m *MockRepository) UpdateProductImage(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProductImage indicates an expected call of UpdateProductImage
+func (mr *MockRepositoryMockRecorder) UpdateProductImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductImage", reflect.TypeOf((*MockRepository)(nil).UpdateProductImage), arg0, arg1)
+}
+
+// UpdateSchedule mocks base method
+func (m *MockRepository) UpdateSchedule(arg0 int, arg1 models.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule
+func (mr *MockRepositoryMockRecorder) UpdateSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockRepository)(nil).UpdateSchedule), arg0, arg1)
+}
+
+// UpdateVendorImage mocks base method
+func (m *MockRepository) UpdateVendorImage(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVendorImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVendorImage indicates an expected call of UpdateVendorImage
+func (mr *MockRepositoryMockRecorder) UpdateVendorImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVendorImage", reflect.TypeOf((*MockRepository)(nil).UpdateVendorImage), arg0, arg1)
+}
@@ -2,6 +2,7 @@ package vendors
 
 import "github.com/friends/internal/pkg/models"
 
+//go:generate mockgen -destination=./repo_mock.go -package=vendors github.com/friends/internal/pkg/vendors Repository
 type Repository interface {
 	Get(id int) (models.Vendor, error)
 	GetVendorInfo(id string) (models.Vendor, error)
@@ -20,8 +21,17 @@ type Repository interface {
 	UpdateProductImage(vendorID string, link string) error
 	GetPartnerShops(partnerID string) ([]models.Vendor, error)
 	GetVendorOwner(vendorID int) (string, error)
-	GetNearest(longitude, latitude float64) ([]models.Vendor, error)
+	GetNearest(longitude, latitude float64, limit, offset int) ([]models.Vendor, error)
 	GetSimilar(vendorID string, longitude, latitude float64) ([]models.Vendor, error)
 	Get3RandomVendors() ([]models.Vendor, error)
 	GetAllCategories() ([]string, error)
+	GetDeliverySettings(vendorID int) (models.DeliverySettings, error)
+	UpdateDeliverySettings(vendorID int, settings models.DeliverySettings) error
+	GetDistance(vendorID int, longitude, latitude float64) (int, error)
+	IsInServiceArea(vendorID int, longitude, latitude float64) (bool, error)
+	GetDeliveryZone(vendorID int) (models.DeliveryZone, error)
+	UpdateDeliveryZone(vendorID int, zone models.DeliveryZone) error
+	GetSchedules(vendorIDs []int) (map[int]models.Schedule, error)
+	UpdateSchedule(vendorID int, schedule models.Schedule) error
+	SetPaused(vendorID int, paused bool) error
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/vendors"
@@ -435,11 +436,18 @@ func (v VendorRepository) GetVendorOwner(vendorID int) (string, error) {
 	return partnerID, nil
 }
 
-func (v VendorRepository) GetNearest(longitude, latitude float64) ([]models.Vendor, error) {
+// GetNearest returns vendors that deliver to the point: the point is inside
+// the delivery zone of the vendor, or inside its service radius when no zone is set.
+func (v VendorRepository) GetNearest(longitude, latitude float64, limit, offset int) ([]models.Vendor, error) {
 	rows, err := v.db.Query(
-		`SELECT id, vendorName, ST_X(coordinates::geometry), ST_Y(coordinates::geometry), service_radius
-		FROM vendors WHERE ST_DWithin(coordinates, ST_SetSRID(ST_Point($1, $2), 4326), 5 * 1000)`,
-		longitude, latitude,
+		`SELECT id, vendorName, ST_X(coordinates::geometry), ST_Y(coordinates::geometry), service_radius,
+		ST_Distance(coordinates, ST_SetSRID(ST_Point($1, $2), 4326)) AS distance
+		FROM vendors WHERE COALESCE(
+			ST_Covers(delivery_zone, ST_SetSRID(ST_Point($1, $2), 4326)),
+			ST_DWithin(coordinates, ST_SetSRID(ST_Point($1, $2), 4326), service_radius * 1000)
+		)
+		ORDER BY distance, id LIMIT $3 OFFSET $4`,
+		longitude, latitude, limit, offset,
 	)
 
 	if err != nil {
@@ -450,11 +458,13 @@ func (v VendorRepository) GetNearest(longitude, latitude float64) ([]models.Vend
 	vendors := make([]models.Vendor, 0)
 	for rows.Next() {
 		vendor := models.Vendor{}
-		err = rows.Scan(&vendor.ID, &vendor.HintContent, &vendor.Longitude, &vendor.Latitude, &vendor.Radius)
+		var distance float64
+		err = rows.Scan(&vendor.ID, &vendor.HintContent, &vendor.Longitude, &vendor.Latitude, &vendor.Radius, &distance)
 		if err != nil {
 			return nil, fmt.Errorf("error in receiving the vendor: %w", err)
 		}
 
+		vendor.DistanceTo = int(math.Round(distance))
 		vendors = append(vendors, vendor)
 	}
 
@@ -462,21 +472,26 @@ func (v VendorRepository) GetNearest(longitude, latitude float64) ([]models.Vend
 }
 
 func (v VendorRepository) GetSimilar(vendorID string, longitude, latitude float64) ([]models.Vendor, error) {
-	var geoCondition string
+	distance := "0"
+	geoCondition := ""
+	args := []interface{}{vendorID}
 	if latitude != 0 && longitude != 0 {
-		geoCondition = fmt.Sprintf(
-			"ST_DWithin(coordinates, ST_SetSRID(ST_Point(%v, %v), 4326), 5 * 1000) AND", longitude, latitude,
-		)
+		distance = "ST_Distance(v.coordinates, ST_SetSRID(ST_Point($2, $3), 4326))"
+		geoCondition = `COALESCE(
+			ST_Covers(v.delivery_zone, ST_SetSRID(ST_Point($2, $3), 4326)),
+			ST_DWithin(v.coordinates, ST_SetSRID(ST_Point($2, $3), 4326), v.service_radius * 1000)
+		) AND`
+		args = append(args, longitude, latitude)
 	}
 
 	rows, err := v.db.Query(
-		fmt.Sprintf(`SELECT v.id, v.vendorName, v.descript, v.picture FROM vendors AS v
+		fmt.Sprintf(`SELECT v.id, v.vendorName, v.descript, v.picture, %v AS distance FROM vendors AS v
 		JOIN vendor_categories AS vc ON v.id = vc.vendorid
 		WHERE %v
 		category IN (SELECT category FROM vendor_categories WHERE vendorid = $1) AND vendorid != $1
 		GROUP BY v.id
-		ORDER BY COUNT(category) DESC`, geoCondition),
-		vendorID,
+		ORDER BY COUNT(category) DESC, distance`, distance, geoCondition),
+		args...,
 	)
 
 	if err != nil {
@@ -485,13 +500,15 @@ func (v VendorRepository) GetSimilar(vendorID string, longitude, latitude float6
 	defer rows.Close()
 
 	vendors := make([]models.Vendor, 0)
-	vendor := models.Vendor{}
 	for rows.Next() {
-		err = rows.Scan(&vendor.ID, &vendor.Name, &vendor.Description, &vendor.Picture)
+		vendor := models.Vendor{}
+		var distance float64
+		err = rows.Scan(&vendor.ID, &vendor.Name, &vendor.Description, &vendor.Picture, &distance)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get recomendations: %w", err)
 		}
 
+		vendor.DistanceTo = int(math.Round(distance))
 		vendors = append(vendors, vendor)
 	}
 
@@ -545,3 +562,366 @@ func (v VendorRepository) GetAllCategories() ([]string, error) {
 
 	return categories, nil
 }
+
+func (v VendorRepository) GetDeliverySettings(vendorID int) (models.DeliverySettings, error) {
+	settings := models.DeliverySettings{
+		FeeTiers: make([]models.DeliveryFeeTier, 0),
+	}
+
+	err := v.db.QueryRow(
+		"SELECT min_order_amount FROM vendors WHERE id = $1",
+		vendorID,
+	).Scan(&settings.MinOrderAmount)
+
+	if err == sql.ErrNoRows {
+		return models.DeliverySettings{}, ownErr.NewClientError(fmt.Errorf("no such vendor"))
+	}
+
+	if err != nil {
+		return models.DeliverySettings{}, ownErr.NewServerError(fmt.Errorf("couldn't get minimum order amount: %w", err))
+	}
+
+	rows, err := v.db.Query(
+		"SELECT max_distance, fee FROM delivery_fee_tiers WHERE vendorID = $1 ORDER BY max_distance",
+		vendorID,
+	)
+
+	if err != nil {
+		return models.DeliverySettings{}, ownErr.NewServerError(fmt.Errorf("couldn't get delivery fee tiers: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tier models.DeliveryFeeTier
+		err = rows.Scan(&tier.MaxDistance, &tier.Fee)
+		if err != nil {
+			return models.DeliverySettings{}, ownErr.NewServerError(fmt.Errorf("couldn't get delivery fee tier: %w", err))
+		}
+
+		settings.FeeTiers = append(settings.FeeTiers, tier)
+	}
+
+	return settings, nil
+}
+
+func (v VendorRepository) UpdateDeliverySettings(vendorID int, settings models.DeliverySettings) error {
+	tx, err := v.db.Begin()
+	if err != nil {
+		return fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"UPDATE vendors SET min_order_amount = $1 WHERE id = $2",
+		settings.MinOrderAmount, vendorID,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't update minimum order amount: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM delivery_fee_tiers WHERE vendorID = $1",
+		vendorID,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't delete delivery fee tiers: %w", err)
+	}
+
+	for _, tier := range settings.FeeTiers {
+		_, err = tx.Exec(
+			"INSERT INTO delivery_fee_tiers (vendorID, max_distance, fee) VALUES ($1, $2, $3)",
+			vendorID, tier.MaxDistance, tier.Fee,
+		)
+
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("couldn't insert delivery fee tier: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDistance returns the distance in meters between the vendor
+// and the point.
+func (v VendorRepository) GetDistance(vendorID int, longitude, latitude float64) (int, error) {
+	var distance float64
+	err := v.db.QueryRow(
+		"SELECT ST_Distance(coordinates, ST_SetSRID(ST_Point($1, $2), 4326)) FROM vendors WHERE id = $3",
+		longitude, latitude, vendorID,
+	).Scan(&distance)
+
+	if err == sql.ErrNoRows {
+		return 0, ownErr.NewClientError(fmt.Errorf("no such vendor"))
+	}
+
+	if err != nil {
+		return 0, ownErr.NewServerError(fmt.Errorf("couldn't get distance to vendor: %w", err))
+	}
+
+	return int(math.Round(distance)), nil
+}
+
+// IsInServiceArea checks that the point is inside the delivery zone
+// of the vendor, or inside its service radius when no zone is set.
+func (v VendorRepository) IsInServiceArea(vendorID int, longitude, latitude float64) (bool, error) {
+	var inArea bool
+	err := v.db.QueryRow(
+		`SELECT COALESCE(
+			ST_Covers(delivery_zone, ST_SetSRID(ST_Point($1, $2), 4326)),
+			ST_DWithin(coordinates, ST_SetSRID(ST_Point($1, $2), 4326), service_radius * 1000)
+		) FROM vendors WHERE id = $3`,
+		longitude, latitude, vendorID,
+	).Scan(&inArea)
+
+	if err == sql.ErrNoRows {
+		return false, ownErr.NewClientError(fmt.Errorf("no such vendor"))
+	}
+
+	if err != nil {
+		return false, ownErr.NewServerError(fmt.Errorf("couldn't check vendor service area: %w", err))
+	}
+
+	return inArea, nil
+}
+
+// GetDeliveryZone returns the polygons of the vendor delivery zone.
+// The zone has no polygons when the vendor delivers within its radius.
+func (v VendorRepository) GetDeliveryZone(vendorID int) (models.DeliveryZone, error) {
+	var geoJSON string
+	err := v.db.QueryRow(
+		"SELECT COALESCE(ST_AsGeoJSON(delivery_zone::geometry), '') FROM vendors WHERE id = $1",
+		vendorID,
+	).Scan(&geoJSON)
+
+	if err == sql.ErrNoRows {
+		return models.DeliveryZone{}, ownErr.NewClientError(fmt.Errorf("no such vendor"))
+	}
+
+	if err != nil {
+		return models.DeliveryZone{}, ownErr.NewServerError(fmt.Errorf("couldn't get delivery zone: %w", err))
+	}
+
+	zone := models.DeliveryZone{
+		Type:        models.GeoJSONMultiPolygon,
+		Coordinates: make([][][][2]float64, 0),
+	}
+	if geoJSON == "" {
+		return zone, nil
+	}
+
+	err = zone.UnmarshalJSON([]byte(geoJSON))
+	if err != nil {
+		return models.DeliveryZone{}, ownErr.NewServerError(fmt.Errorf("couldn't parse delivery zone: %w", err))
+	}
+
+	return zone, nil
+}
+
+// UpdateDeliveryZone replaces the vendor delivery zone. A zone
+// without polygons is removed.
+func (v VendorRepository) UpdateDeliveryZone(vendorID int, zone models.DeliveryZone) error {
+	if len(zone.Coordinates) == 0 {
+		_, err := v.db.Exec("UPDATE vendors SET delivery_zone = NULL WHERE id = $1", vendorID)
+		if err != nil {
+			return ownErr.NewServerError(fmt.Errorf("couldn't remove delivery zone: %w", err))
+		}
+
+		return nil
+	}
+
+	geoJSON, err := zone.MarshalJSON()
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't encode delivery zone: %w", err))
+	}
+
+	result, err := v.db.Exec(
+		`UPDATE vendors SET delivery_zone = ST_SetSRID(ST_GeomFromGeoJSON($1), 4326)::geography
+		WHERE id = $2 AND ST_IsValid(ST_GeomFromGeoJSON($1))`,
+		string(geoJSON), vendorID,
+	)
+
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't update delivery zone: %w", err))
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ownErr.NewServerError(fmt.Errorf("couldn't update delivery zone: %w", err))
+	}
+
+	if affected == 0 {
+		return ownErr.NewClientError(fmt.Errorf("%w: invalid geometry", vendors.ErrWrongZone))
+	}
+
+	return nil
+}
+
+// GetSchedules returns the schedules of the existing vendors from the list.
+func (v VendorRepository) GetSchedules(vendorIDs []int) (map[int]models.Schedule, error) {
+	rows, err := v.db.Query(
+		"SELECT id, time_zone, paused FROM vendors WHERE id = ANY ($1)",
+		pq.Array(vendorIDs),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get vendor time zones: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make(map[int]models.Schedule, len(vendorIDs))
+	for rows.Next() {
+		var vendorID int
+		schedule := models.Schedule{
+			Hours:    make([]models.OpeningHours, 0),
+			Holidays: make([]string, 0),
+		}
+
+		err = rows.Scan(&vendorID, &schedule.TimeZone, &schedule.Paused)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get vendor time zone: %w", err)
+		}
+
+		schedules[vendorID] = schedule
+	}
+
+	hoursRows, err := v.db.Query(
+		`SELECT vendorID, weekday, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		FROM opening_hours WHERE vendorID = ANY ($1) ORDER BY vendorID, weekday, opens_at`,
+		pq.Array(vendorIDs),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get opening hours: %w", err)
+	}
+	defer hoursRows.Close()
+
+	for hoursRows.Next() {
+		var vendorID int
+		var hours models.OpeningHours
+		err = hoursRows.Scan(&vendorID, &hours.Weekday, &hours.Opens, &hours.Closes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get opening hours: %w", err)
+		}
+
+		schedule := schedules[vendorID]
+		schedule.Hours = append(schedule.Hours, hours)
+		schedules[vendorID] = schedule
+	}
+
+	holidayRows, err := v.db.Query(
+		"SELECT vendorID, to_char(day, 'YYYY-MM-DD') FROM vendor_holidays WHERE vendorID = ANY ($1) ORDER BY day",
+		pq.Array(vendorIDs),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get holidays: %w", err)
+	}
+	defer holidayRows.Close()
+
+	for holidayRows.Next() {
+		var vendorID int
+		var holiday string
+		err = holidayRows.Scan(&vendorID, &holiday)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get holiday: %w", err)
+		}
+
+		schedule := schedules[vendorID]
+		schedule.Holidays = append(schedule.Holidays, holiday)
+		schedules[vendorID] = schedule
+	}
+
+	return schedules, nil
+}
+
+func (v VendorRepository) UpdateSchedule(vendorID int, schedule models.Schedule) error {
+	tx, err := v.db.Begin()
+	if err != nil {
+		return fmt.Errorf("couldn't create transaction: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"UPDATE vendors SET time_zone = $1, paused = $2 WHERE id = $3",
+		schedule.TimeZone, schedule.Paused, vendorID,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't update time zone and pause: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM opening_hours WHERE vendorID = $1",
+		vendorID,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't delete opening hours: %w", err)
+	}
+
+	for _, hours := range schedule.Hours {
+		_, err = tx.Exec(
+			"INSERT INTO opening_hours (vendorID, weekday, opens_at, closes_at) VALUES ($1, $2, $3, $4)",
+			vendorID, hours.Weekday, hours.Opens, hours.Closes,
+		)
+
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("couldn't insert opening hours: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM vendor_holidays WHERE vendorID = $1",
+		vendorID,
+	)
+
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't delete holidays: %w", err)
+	}
+
+	for _, holiday := range schedule.Holidays {
+		_, err = tx.Exec(
+			"INSERT INTO vendor_holidays (vendorID, day) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			vendorID, holiday,
+		)
+
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("couldn't insert holiday: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("couldn't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (v VendorRepository) SetPaused(vendorID int, paused bool) error {
+	_, err := v.db.Exec(
+		"UPDATE vendors SET paused = $1 WHERE id = $2",
+		paused, vendorID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("couldn't update vendor pause: %w", err)
+	}
+
+	return nil
+}
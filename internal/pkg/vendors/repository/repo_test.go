@@ -9,6 +9,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/friends/internal/pkg/models"
+	ownErr "github.com/friends/pkg/error"
 	"github.com/lib/pq"
 )
 
@@ -1272,18 +1273,18 @@ func TestGetNeares(t *testing.T) {
 
 	repo := NewVendorRepository(db)
 
-	rows := mock.NewRows([]string{"id", "vendorName", "ST_X(coordinates::geometry)", "ST_Y(coordinates::geometry)", "service_radius"})
+	rows := mock.NewRows([]string{"id", "vendorName", "ST_X(coordinates::geometry)", "ST_Y(coordinates::geometry)", "service_radius", "distance"})
 	for i := 0; i < 2; i++ {
-		rows.AddRow(testVendor.ID, testVendor.Name, testVendor.Longitude, testVendor.Latitude, testVendor.Radius)
+		rows.AddRow(testVendor.ID, testVendor.Name, testVendor.Longitude, testVendor.Latitude, testVendor.Radius, 1234.6)
 	}
 
 	// good query
 	mock.
 		ExpectQuery("SELECT").
-		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude)).
+		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0).
 		WillReturnRows(rows)
 
-	vs, err := repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude))
+	vs, err := repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0)
 
 	for _, v := range vs {
 		if v.ID != testVendor.ID &&
@@ -1293,6 +1294,9 @@ func TestGetNeares(t *testing.T) {
 			v.Radius != testVendor.Radius {
 			t.Errorf("expected: %v\n got: %v", testVendor, v)
 		}
+		if v.DistanceTo != 1235 {
+			t.Errorf("expected: %v\n got: %v", 1235, v.DistanceTo)
+		}
 	}
 
 	if err != nil {
@@ -1302,10 +1306,10 @@ func TestGetNeares(t *testing.T) {
 	// bad query
 	mock.
 		ExpectQuery("SELECT").
-		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude)).
+		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0).
 		WillReturnError(dbError)
 
-	vs, err = repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude))
+	vs, err = repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0)
 
 	if len(vs) != 0 {
 		t.Errorf("expected: []\n got: %v", vs)
@@ -1324,10 +1328,10 @@ func TestGetNeares(t *testing.T) {
 	// good query
 	mock.
 		ExpectQuery("SELECT").
-		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude)).
+		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0).
 		WillReturnRows(rows)
 
-	vs, err = repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude))
+	vs, err = repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0)
 
 	if len(vs) != 0 {
 		t.Errorf("expected: []\n got: %v", vs)
@@ -1337,3 +1341,624 @@ func TestGetNeares(t *testing.T) {
 		t.Errorf("expected err")
 	}
 }
+
+func TestGetNearestDeliveryZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	// the vendor delivers to the point through its delivery zone
+	rows := mock.NewRows([]string{"id", "vendorName", "ST_X(coordinates::geometry)", "ST_Y(coordinates::geometry)", "service_radius", "distance"})
+	rows.AddRow(testVendor.ID, testVendor.Name, testVendor.Longitude, testVendor.Latitude, testVendor.Radius, 9000.0)
+
+	mock.
+		ExpectQuery(`COALESCE\(\s*ST_Covers\(delivery_zone, .*\),\s*ST_DWithin\(coordinates`).
+		WithArgs(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0).
+		WillReturnRows(rows)
+
+	vs, err := repo.GetNearest(float64(testVendor.Longitude), float64(testVendor.Latitude), 10, 0)
+
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if len(vs) != 1 || vs[0].ID != testVendor.ID || vs[0].DistanceTo != 9000 {
+		t.Errorf("expected vendor %v at 9000\n got: %v", testVendor.ID, vs)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSimilar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	vendorID := strconv.Itoa(testVendor.ID)
+
+	// with the user location the delivery zone is checked
+	rows := mock.NewRows([]string{"id", "vendorName", "descript", "picture", "distance"})
+	rows.AddRow(2, testVendor.Name, testVendor.Description, testVendor.Picture, 1234.6)
+
+	mock.
+		ExpectQuery(`COALESCE\(\s*ST_Covers\(v.delivery_zone, .*\),\s*ST_DWithin\(v.coordinates`).
+		WithArgs(vendorID, 37.6, 55.7).
+		WillReturnRows(rows)
+
+	vs, err := repo.GetSimilar(vendorID, 37.6, 55.7)
+
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if len(vs) != 1 || vs[0].ID != 2 || vs[0].DistanceTo != 1235 {
+		t.Errorf("expected vendor 2 at 1235\n got: %v", vs)
+	}
+
+	// without the user location
+	rows = mock.NewRows([]string{"id", "vendorName", "descript", "picture", "distance"})
+	rows.AddRow(2, testVendor.Name, testVendor.Description, testVendor.Picture, 0)
+
+	mock.
+		ExpectQuery("SELECT v.id").
+		WithArgs(vendorID).
+		WillReturnRows(rows)
+
+	vs, err = repo.GetSimilar(vendorID, 0, 0)
+
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	if len(vs) != 1 {
+		t.Errorf("expected one vendor\n got: %v", vs)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT v.id").
+		WithArgs(vendorID, 37.6, 55.7).
+		WillReturnError(dbError)
+
+	_, err = repo.GetSimilar(vendorID, 37.6, 55.7)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDeliverySettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	expected := models.DeliverySettings{
+		MinOrderAmount: 500,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 2000, Fee: 50}, {MaxDistance: 5000, Fee: 150}},
+	}
+
+	// good query
+	mock.
+		ExpectQuery("SELECT min_order_amount FROM vendors").
+		WithArgs(testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"min_order_amount"}).AddRow(expected.MinOrderAmount))
+
+	rows := mock.NewRows([]string{"max_distance", "fee"})
+	for _, tier := range expected.FeeTiers {
+		rows.AddRow(tier.MaxDistance, tier.Fee)
+	}
+
+	mock.
+		ExpectQuery("SELECT max_distance, fee FROM delivery_fee_tiers").
+		WithArgs(testVendor.ID).
+		WillReturnRows(rows)
+
+	settings, err := repo.GetDeliverySettings(testVendor.ID)
+
+	if !reflect.DeepEqual(expected, settings) {
+		t.Errorf("expected: %v\n got: %v", expected, settings)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// no vendor
+	mock.
+		ExpectQuery("SELECT min_order_amount FROM vendors").
+		WithArgs(testVendor.ID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetDeliverySettings(testVendor.ID)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT min_order_amount FROM vendors").
+		WithArgs(testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"min_order_amount"}).AddRow(expected.MinOrderAmount))
+
+	mock.
+		ExpectQuery("SELECT max_distance, fee FROM delivery_fee_tiers").
+		WithArgs(testVendor.ID).
+		WillReturnError(dbError)
+
+	_, err = repo.GetDeliverySettings(testVendor.ID)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
+
+func TestUpdateDeliverySettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	settings := models.DeliverySettings{
+		MinOrderAmount: 500,
+		FeeTiers:       []models.DeliveryFeeTier{{MaxDistance: 2000, Fee: 50}},
+	}
+
+	// good query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("UPDATE vendors SET min_order_amount").
+		WithArgs(settings.MinOrderAmount, testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.
+		ExpectExec("DELETE FROM delivery_fee_tiers").
+		WithArgs(testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.
+		ExpectExec("INSERT INTO delivery_fee_tiers").
+		WithArgs(testVendor.ID, 2000, 50).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.UpdateDeliverySettings(testVendor.ID, settings)
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("UPDATE vendors SET min_order_amount").
+		WithArgs(settings.MinOrderAmount, testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.
+		ExpectExec("DELETE FROM delivery_fee_tiers").
+		WithArgs(testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.
+		ExpectExec("INSERT INTO delivery_fee_tiers").
+		WithArgs(testVendor.ID, 2000, 50).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	err = repo.UpdateDeliverySettings(testVendor.ID, settings)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDistance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	// good query
+	mock.
+		ExpectQuery("SELECT ST_Distance").
+		WithArgs(37.6, 55.7, testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"st_distance"}).AddRow(1234.6))
+
+	distance, err := repo.GetDistance(testVendor.ID, 37.6, 55.7)
+
+	if distance != 1235 {
+		t.Errorf("expected: %v\n got: %v", 1235, distance)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT ST_Distance").
+		WithArgs(37.6, 55.7, testVendor.ID).
+		WillReturnError(dbError)
+
+	_, err = repo.GetDistance(testVendor.ID, 37.6, 55.7)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
+
+func TestIsInServiceArea(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	// good query
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(37.6, 55.7, testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"st_dwithin"}).AddRow(true))
+
+	inArea, err := repo.IsInServiceArea(testVendor.ID, 37.6, 55.7)
+
+	if !inArea {
+		t.Errorf("expected: %v\n got: %v", true, inArea)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// no vendor
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(37.6, 55.7, testVendor.ID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.IsInServiceArea(testVendor.ID, 37.6, 55.7)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(37.6, 55.7, testVendor.ID).
+		WillReturnError(dbError)
+
+	_, err = repo.IsInServiceArea(testVendor.ID, 37.6, 55.7)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
+
+func TestGetDeliveryZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	geoJSON := `{"type":"MultiPolygon","coordinates":[[[[37.5,55.7],[37.7,55.7],[37.7,55.8],[37.5,55.7]]]]}`
+	expected := models.DeliveryZone{
+		Type:        models.GeoJSONMultiPolygon,
+		Coordinates: [][][][2]float64{{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.7}}}},
+	}
+
+	// good query
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"coalesce"}).AddRow(geoJSON))
+
+	zone, err := repo.GetDeliveryZone(testVendor.ID)
+
+	if !reflect.DeepEqual(zone, expected) {
+		t.Errorf("expected: %v\n got: %v", expected, zone)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// no zone
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(testVendor.ID).
+		WillReturnRows(mock.NewRows([]string{"coalesce"}).AddRow(""))
+
+	zone, err = repo.GetDeliveryZone(testVendor.ID)
+
+	if len(zone.Coordinates) != 0 || zone.Type != models.GeoJSONMultiPolygon {
+		t.Errorf("expected empty zone\n got: %v", zone)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// no vendor
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(testVendor.ID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetDeliveryZone(testVendor.ID)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error\n got: %v", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT COALESCE").
+		WithArgs(testVendor.ID).
+		WillReturnError(dbError)
+
+	_, err = repo.GetDeliveryZone(testVendor.ID)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
+
+func TestUpdateDeliveryZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	zone := models.DeliveryZone{
+		Type:        models.GeoJSONMultiPolygon,
+		Coordinates: [][][][2]float64{{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.7}}}},
+	}
+	geoJSON, _ := zone.MarshalJSON()
+
+	// good query
+	mock.
+		ExpectExec("UPDATE vendors SET delivery_zone").
+		WithArgs(string(geoJSON), testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateDeliveryZone(testVendor.ID, zone)
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// invalid geometry
+	mock.
+		ExpectExec("UPDATE vendors SET delivery_zone").
+		WithArgs(string(geoJSON), testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.UpdateDeliveryZone(testVendor.ID, zone)
+
+	reqErr, ok := err.(ownErr.RequestError)
+	if !ok || !reqErr.IsClientError() {
+		t.Errorf("expected client error\n got: %v", err)
+	}
+
+	// remove zone
+	mock.
+		ExpectExec("UPDATE vendors SET delivery_zone = NULL").
+		WithArgs(testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateDeliveryZone(testVendor.ID, models.DeliveryZone{Type: models.GeoJSONMultiPolygon})
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("UPDATE vendors SET delivery_zone").
+		WithArgs(string(geoJSON), testVendor.ID).
+		WillReturnError(dbError)
+
+	err = repo.UpdateDeliveryZone(testVendor.ID, zone)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSchedules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	ids := []int{testVendor.ID}
+	expected := map[int]models.Schedule{
+		testVendor.ID: {
+			TimeZone: "Europe/Moscow",
+			Hours:    []models.OpeningHours{{Weekday: 1, Opens: "10:00", Closes: "22:00"}},
+			Holidays: []string{"2021-01-01"},
+		},
+	}
+
+	// good query
+	mock.
+		ExpectQuery("SELECT id, time_zone, paused FROM vendors").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(mock.NewRows([]string{"id", "time_zone", "paused"}).AddRow(testVendor.ID, "Europe/Moscow", false))
+	mock.
+		ExpectQuery("SELECT vendorID, weekday").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(mock.NewRows([]string{"vendorID", "weekday", "opens_at", "closes_at"}).
+			AddRow(testVendor.ID, 1, "10:00", "22:00"))
+	mock.
+		ExpectQuery("SELECT vendorID, to_char").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(mock.NewRows([]string{"vendorID", "day"}).AddRow(testVendor.ID, "2021-01-01"))
+
+	schedules, err := repo.GetSchedules(ids)
+
+	if !reflect.DeepEqual(schedules, expected) {
+		t.Errorf("expected: %v\n got: %v", expected, schedules)
+	}
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.
+		ExpectQuery("SELECT id, time_zone, paused FROM vendors").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(mock.NewRows([]string{"id", "time_zone", "paused"}).AddRow(testVendor.ID, "Europe/Moscow", false))
+	mock.
+		ExpectQuery("SELECT vendorID, weekday").
+		WithArgs(pq.Array(ids)).
+		WillReturnError(dbError)
+
+	_, err = repo.GetSchedules(ids)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	schedule := models.Schedule{
+		TimeZone: "Europe/Moscow",
+		Hours:    []models.OpeningHours{{Weekday: 1, Opens: "10:00", Closes: "22:00"}},
+		Holidays: []string{"2021-01-01"},
+		Paused:   true,
+	}
+
+	// good query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("UPDATE vendors SET time_zone").
+		WithArgs(schedule.TimeZone, true, testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.
+		ExpectExec("DELETE FROM opening_hours").
+		WithArgs(testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.
+		ExpectExec("INSERT INTO opening_hours").
+		WithArgs(testVendor.ID, 1, "10:00", "22:00").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.
+		ExpectExec("DELETE FROM vendor_holidays").
+		WithArgs(testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.
+		ExpectExec("INSERT INTO vendor_holidays").
+		WithArgs(testVendor.ID, "2021-01-01").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.UpdateSchedule(testVendor.ID, schedule)
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.ExpectBegin()
+	mock.
+		ExpectExec("UPDATE vendors SET time_zone").
+		WithArgs(schedule.TimeZone, true, testVendor.ID).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	err = repo.UpdateSchedule(testVendor.ID, schedule)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSetPaused(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(fatalError, err)
+	}
+	defer db.Close()
+
+	repo := NewVendorRepository(db)
+
+	// good query
+	mock.
+		ExpectExec("UPDATE vendors SET paused").
+		WithArgs(true, testVendor.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetPaused(testVendor.ID, true)
+
+	if err != nil {
+		t.Errorf("unexpected err: %s", err)
+	}
+
+	// bad query
+	mock.
+		ExpectExec("UPDATE vendors SET paused").
+		WithArgs(true, testVendor.ID).
+		WillReturnError(dbError)
+
+	err = repo.SetPaused(testVendor.ID, true)
+
+	if err == nil {
+		t.Errorf("expected err")
+	}
+}
@@ -0,0 +1,125 @@
+package vendors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/models"
+)
+
+var (
+	ErrVendorClosed  = errors.New("vendor is closed")
+	ErrWrongSchedule = errors.New("wrong schedule")
+)
+
+// IsOpen checks the schedule at the given moment in the vendor time zone.
+// Vendors without opening hours are open around the clock unless paused
+// or on a holiday.
+func IsOpen(schedule models.Schedule, now time.Time) (bool, error) {
+	if schedule.Paused {
+		return false, nil
+	}
+
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrWrongSchedule, err)
+	}
+
+	local := now.In(location)
+	date := local.Format(configs.HolidayFormat)
+	for _, holiday := range schedule.Holidays {
+		if holiday == date {
+			return false, nil
+		}
+	}
+
+	if len(schedule.Hours) == 0 {
+		return true, nil
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	today := int(local.Weekday())
+	yesterday := (today + 6) % 7
+
+	for _, hours := range schedule.Hours {
+		opens, closes, err := parseHours(hours)
+		if err != nil {
+			return false, err
+		}
+
+		overnight := closes <= opens
+		if hours.Weekday == today && minute >= opens && (overnight || minute < closes) {
+			return true, nil
+		}
+
+		if hours.Weekday == yesterday && overnight && minute < closes {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsVendorOpen loads the vendor schedule and checks it at the given moment.
+func IsVendorOpen(repository Repository, vendorID int, now time.Time) (bool, error) {
+	schedules, err := repository.GetSchedules([]int{vendorID})
+	if err != nil {
+		return false, err
+	}
+
+	schedule, ok := schedules[vendorID]
+	if !ok {
+		return false, fmt.Errorf("no schedule for vendor %v", vendorID)
+	}
+
+	return IsOpen(schedule, now)
+}
+
+// CheckSchedule validates the schedule configured by a partner.
+func CheckSchedule(schedule models.Schedule) error {
+	if schedule.TimeZone == "" {
+		return fmt.Errorf("%w: no time zone", ErrWrongSchedule)
+	}
+
+	_, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrongSchedule, err)
+	}
+
+	for _, hours := range schedule.Hours {
+		if hours.Weekday < 0 || hours.Weekday > 6 {
+			return fmt.Errorf("%w: weekday %v", ErrWrongSchedule, hours.Weekday)
+		}
+
+		_, _, err = parseHours(hours)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, holiday := range schedule.Holidays {
+		_, err = time.Parse(configs.HolidayFormat, holiday)
+		if err != nil {
+			return fmt.Errorf("%w: holiday %q", ErrWrongSchedule, holiday)
+		}
+	}
+
+	return nil
+}
+
+// parseHours returns opening and closing time in minutes since midnight.
+func parseHours(hours models.OpeningHours) (int, int, error) {
+	opens, err := time.Parse(configs.OpeningTimeFormat, hours.Opens)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: opening time %q", ErrWrongSchedule, hours.Opens)
+	}
+
+	closes, err := time.Parse(configs.OpeningTimeFormat, hours.Closes)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: closing time %q", ErrWrongSchedule, hours.Closes)
+	}
+
+	return opens.Hour()*60 + opens.Minute(), closes.Hour()*60 + closes.Minute(), nil
+}
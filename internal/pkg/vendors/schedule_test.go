@@ -0,0 +1,115 @@
+package vendors
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+func TestIsOpen(t *testing.T) {
+	schedule := models.Schedule{
+		TimeZone: "Europe/Moscow",
+		Hours: []models.OpeningHours{
+			{Weekday: 1, Opens: "10:00", Closes: "22:00"},
+			{Weekday: 5, Opens: "18:00", Closes: "02:00"},
+		},
+		Holidays: []string{"2021-01-04"},
+	}
+
+	tests := []struct {
+		now  string
+		open bool
+	}{
+		// Monday 09:59 in Moscow
+		{now: "2021-01-11T06:59:00Z", open: false},
+		// Monday 10:00 in Moscow
+		{now: "2021-01-11T07:00:00Z", open: true},
+		// Monday 22:00 in Moscow
+		{now: "2021-01-11T19:00:00Z", open: false},
+		// Monday holiday
+		{now: "2021-01-04T12:00:00Z", open: false},
+		// Friday 23:00 in Moscow
+		{now: "2021-01-15T20:00:00Z", open: true},
+		// Saturday 01:30 in Moscow after the Friday night opening
+		{now: "2021-01-15T22:30:00Z", open: true},
+		// Saturday 02:00 in Moscow
+		{now: "2021-01-15T23:00:00Z", open: false},
+		// Sunday
+		{now: "2021-01-17T12:00:00Z", open: false},
+	}
+
+	for _, test := range tests {
+		now, _ := time.Parse(time.RFC3339, test.now)
+		open, err := IsOpen(schedule, now)
+
+		if err != nil {
+			t.Errorf("%v. unexpected err: %v", test.now, err)
+		}
+
+		if open != test.open {
+			t.Errorf("%v. expected: %v\n got: %v", test.now, test.open, open)
+		}
+	}
+
+	now, _ := time.Parse(time.RFC3339, "2021-01-11T12:00:00Z")
+
+	open, _ := IsOpen(models.Schedule{TimeZone: "UTC"}, now)
+	if !open {
+		t.Errorf("expected vendor without hours to be open")
+	}
+
+	open, _ = IsOpen(models.Schedule{TimeZone: "UTC", Paused: true}, now)
+	if open {
+		t.Errorf("expected paused vendor to be closed")
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	tests := []struct {
+		schedule models.Schedule
+		err      error
+	}{
+		{
+			schedule: models.Schedule{
+				TimeZone: "Europe/Moscow",
+				Hours:    []models.OpeningHours{{Weekday: 0, Opens: "00:00", Closes: "00:00"}},
+				Holidays: []string{"2021-12-31"},
+			},
+		},
+		{
+			schedule: models.Schedule{},
+			err:      ErrWrongSchedule,
+		},
+		{
+			schedule: models.Schedule{TimeZone: "Mars/Olympus"},
+			err:      ErrWrongSchedule,
+		},
+		{
+			schedule: models.Schedule{
+				TimeZone: "UTC",
+				Hours:    []models.OpeningHours{{Weekday: 7, Opens: "10:00", Closes: "22:00"}},
+			},
+			err: ErrWrongSchedule,
+		},
+		{
+			schedule: models.Schedule{
+				TimeZone: "UTC",
+				Hours:    []models.OpeningHours{{Weekday: 1, Opens: "10", Closes: "22:00"}},
+			},
+			err: ErrWrongSchedule,
+		},
+		{
+			schedule: models.Schedule{TimeZone: "UTC", Holidays: []string{"31.12.2021"}},
+			err:      ErrWrongSchedule,
+		},
+	}
+
+	for i, test := range tests {
+		err := CheckSchedule(test.schedule)
+		if !errors.Is(err, test.err) {
+			t.Errorf("case %v. expected: %v\n got: %v", i, test.err, err)
+		}
+	}
+}
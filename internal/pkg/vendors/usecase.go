@@ -22,7 +22,14 @@ type Usecase interface {
 	GetVendorIDFromProduct(productID string) (string, error)
 	GetPartnerShops(partnerID string) ([]models.Vendor, error)
 	GetVendorOwner(vendorID int) (string, error)
-	GetNearest(longitude, latitude float64) ([]models.Vendor, error)
+	GetNearest(longitude, latitude float64, limit, offset int) ([]models.Vendor, error)
 	GetSimilar(vendorID string, longitude, latitude float64) ([]models.Vendor, error)
 	GetAllCategories() ([]string, error)
+	GetDeliverySettings(vendorID int) (models.DeliverySettings, error)
+	UpdateDeliverySettings(vendorID int, settings models.DeliverySettings) error
+	GetDeliveryZone(vendorID int) (models.DeliveryZone, error)
+	UpdateDeliveryZone(vendorID int, zone models.DeliveryZone) error
+	GetSchedule(vendorID int) (models.Schedule, error)
+	UpdateSchedule(vendorID int, schedule models.Schedule) error
+	SetPaused(vendorID int, paused bool) error
 }
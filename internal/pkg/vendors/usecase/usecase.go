@@ -1,16 +1,14 @@
 package usecase
 
 import (
-	"context"
 	"fmt"
-	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/friends/internal/pkg/fileserver"
 	"github.com/friends/internal/pkg/models"
 	"github.com/friends/internal/pkg/vendors"
-	"github.com/lithammer/shortuuid"
-	"google.golang.org/grpc/metadata"
+	ownErr "github.com/friends/pkg/error"
 )
 
 type VendorUsecase struct {
@@ -26,7 +24,17 @@ func NewVendorUsecase(repo vendors.Repository, fileserverClient fileserver.Uploa
 }
 
 func (v VendorUsecase) Get(id int) (models.Vendor, error) {
-	return v.repository.Get(id)
+	vendor, err := v.repository.Get(id)
+	if err != nil {
+		return models.Vendor{}, err
+	}
+
+	vendorList, err := v.withOpenNow([]models.Vendor{vendor})
+	if err != nil {
+		return models.Vendor{}, err
+	}
+
+	return vendorList[0], nil
 }
 
 func (v VendorUsecase) GetVendorInfo(id string) (models.Vendor, error) {
@@ -34,7 +42,12 @@ func (v VendorUsecase) GetVendorInfo(id string) (models.Vendor, error) {
 }
 
 func (v VendorUsecase) GetAll() ([]models.Vendor, error) {
-	return v.repository.GetAll()
+	vendorList, err := v.repository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return v.withOpenNow(vendorList)
 }
 
 func (v VendorUsecase) Create(partnerID string, vendor models.Vendor) (int, error) {
@@ -67,44 +80,7 @@ func (v VendorUsecase) DeleteProduct(productID string) error {
 }
 
 func (v VendorUsecase) UpdatePicture(file multipart.File, imageType string) (string, error) {
-	imgName := shortuuid.New()
-	imgFullName := imgName + imageType
-
-	md := metadata.New(map[string]string{"fileName": imgFullName})
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
-
-	stream, err := v.fsClient.Upload(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	var (
-		size  int
-		chunk = make([]byte, 1024)
-	)
-
-	for {
-		size, err = file.Read(chunk)
-		if err == io.EOF {
-			break
-		}
-
-		if err != nil {
-			return "", err
-		}
-
-		err = stream.Send(&fileserver.Chunk{Content: chunk[:size]})
-		if err != nil {
-			return "", err
-		}
-	}
-
-	_, err = stream.CloseAndRecv()
-	if err != nil {
-		return "", err
-	}
-
-	return imgFullName, nil
+	return fileserver.Upload(v.fsClient, file, imageType)
 }
 
 func (v VendorUsecase) UpdateVendorPicture(vendorID string, file multipart.File, imgType string) (string, error) {
@@ -140,15 +116,25 @@ func (v VendorUsecase) GetVendorIDFromProduct(productID string) (string, error)
 }
 
 func (v VendorUsecase) GetPartnerShops(partnerID string) ([]models.Vendor, error) {
-	return v.repository.GetPartnerShops(partnerID)
+	vendorList, err := v.repository.GetPartnerShops(partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.withOpenNow(vendorList)
 }
 
 func (v VendorUsecase) GetVendorOwner(vendorID int) (string, error) {
 	return v.repository.GetVendorOwner(vendorID)
 }
 
-func (v VendorUsecase) GetNearest(longitude, latitude float64) ([]models.Vendor, error) {
-	return v.repository.GetNearest(longitude, latitude)
+func (v VendorUsecase) GetNearest(longitude, latitude float64, limit, offset int) ([]models.Vendor, error) {
+	vendorList, err := v.repository.GetNearest(longitude, latitude, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.withOpenNow(vendorList)
 }
 
 func (v VendorUsecase) GetSimilar(vendorID string, longitude, latitude float64) ([]models.Vendor, error) {
@@ -158,7 +144,7 @@ func (v VendorUsecase) GetSimilar(vendorID string, longitude, latitude float64)
 	}
 
 	if len(vendors) >= 3 {
-		return vendors[:3], nil
+		return v.withOpenNow(vendors[:3])
 	}
 
 	moreVendors, err := v.repository.Get3RandomVendors()
@@ -179,9 +165,114 @@ func (v VendorUsecase) GetSimilar(vendorID string, longitude, latitude float64)
 		vendors = append(vendors, vendor)
 	}
 
-	return vendors, nil
+	return v.withOpenNow(vendors)
 }
 
 func (v VendorUsecase) GetAllCategories() ([]string, error) {
 	return v.repository.GetAllCategories()
 }
+
+func (v VendorUsecase) GetDeliverySettings(vendorID int) (models.DeliverySettings, error) {
+	return v.repository.GetDeliverySettings(vendorID)
+}
+
+func (v VendorUsecase) UpdateDeliverySettings(vendorID int, settings models.DeliverySettings) error {
+	err := vendors.CheckDeliverySettings(settings)
+	if err != nil {
+		return ownErr.NewClientError(err)
+	}
+
+	err = v.repository.UpdateDeliverySettings(vendorID, settings)
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	return nil
+}
+
+func (v VendorUsecase) GetDeliveryZone(vendorID int) (models.DeliveryZone, error) {
+	return v.repository.GetDeliveryZone(vendorID)
+}
+
+func (v VendorUsecase) UpdateDeliveryZone(vendorID int, zone models.DeliveryZone) error {
+	err := vendors.CheckDeliveryZone(zone)
+	if err != nil {
+		return ownErr.NewClientError(err)
+	}
+
+	return v.repository.UpdateDeliveryZone(vendorID, zone)
+}
+
+func (v VendorUsecase) GetSchedule(vendorID int) (models.Schedule, error) {
+	schedules, err := v.repository.GetSchedules([]int{vendorID})
+	if err != nil {
+		return models.Schedule{}, ownErr.NewServerError(err)
+	}
+
+	schedule, ok := schedules[vendorID]
+	if !ok {
+		return models.Schedule{}, ownErr.NewClientError(fmt.Errorf("no such vendor"))
+	}
+
+	schedule.IsOpenNow, err = vendors.IsOpen(schedule, time.Now())
+	if err != nil {
+		return models.Schedule{}, ownErr.NewServerError(err)
+	}
+
+	return schedule, nil
+}
+
+func (v VendorUsecase) UpdateSchedule(vendorID int, schedule models.Schedule) error {
+	err := vendors.CheckSchedule(schedule)
+	if err != nil {
+		return ownErr.NewClientError(err)
+	}
+
+	err = v.repository.UpdateSchedule(vendorID, schedule)
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	return nil
+}
+
+func (v VendorUsecase) SetPaused(vendorID int, paused bool) error {
+	err := v.repository.SetPaused(vendorID, paused)
+	if err != nil {
+		return ownErr.NewServerError(err)
+	}
+
+	return nil
+}
+
+// withOpenNow sets whether each vendor of the list is open at the moment.
+func (v VendorUsecase) withOpenNow(vendorList []models.Vendor) ([]models.Vendor, error) {
+	if len(vendorList) == 0 {
+		return vendorList, nil
+	}
+
+	ids := make([]int, 0, len(vendorList))
+	for _, vendor := range vendorList {
+		ids = append(ids, vendor.ID)
+	}
+
+	schedules, err := v.repository.GetSchedules(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for idx := range vendorList {
+		schedule, ok := schedules[vendorList[idx].ID]
+		if !ok {
+			continue
+		}
+
+		vendorList[idx].IsOpenNow, err = vendors.IsOpen(schedule, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vendorList, nil
+}
@@ -137,19 +137,49 @@ func (mr *MockUsecaseMockRecorder) GetAllCategories() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockUsecase)(nil).GetAllCategories))
 }
 
+// GetDeliverySettings mocks base method
+func (m *MockUsecase) GetDeliverySettings(arg0 int) (models.DeliverySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliverySettings", arg0)
+	ret0, _ := ret[0].(models.DeliverySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliverySettings indicates an expected call of GetDeliverySettings
+func (mr *MockUsecaseMockRecorder) GetDeliverySettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliverySettings", reflect.TypeOf((*MockUsecase)(nil).GetDeliverySettings), arg0)
+}
+
+// GetDeliveryZone mocks base method
+func (m *MockUsecase) GetDeliveryZone(arg0 int) (models.DeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryZone", arg0)
+	ret0, _ := ret[0].(models.DeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryZone indicates an expected call of GetDeliveryZone
+func (mr *MockUsecaseMockRecorder) GetDeliveryZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryZone", reflect.TypeOf((*MockUsecase)(nil).GetDeliveryZone), arg0)
+}
+
 // GetNearest mocks base method
-func (m *MockUsecase) GetNearest(arg0, arg1 float64) ([]models.Vendor, error) {
+func (m *MockUsecase) GetNearest(arg0, arg1 float64, arg2, arg3 int) ([]models.Vendor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNearest", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetNearest", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]models.Vendor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNearest indicates an expected call of GetNearest
-func (mr *MockUsecaseMockRecorder) GetNearest(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) GetNearest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearest", reflect.TypeOf((*MockUsecase)(nil).GetNearest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearest", reflect.TypeOf((*MockUsecase)(nil).GetNearest), arg0, arg1, arg2, arg3)
 }
 
 // GetPartnerShops mocks base method
@@ -167,6 +197,21 @@ func (mr *MockUsecaseMockRecorder) GetPartnerShops(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerShops", reflect.TypeOf((*MockUsecase)(nil).GetPartnerShops), arg0)
 }
 
+// GetSchedule mocks base method
+func (m *MockUsecase) GetSchedule(arg0 int) (models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0)
+	ret0, _ := ret[0].(models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule
+func (mr *MockUsecaseMockRecorder) GetSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockUsecase)(nil).GetSchedule), arg0)
+}
+
 // GetSimilar mocks base method
 func (m *MockUsecase) GetSimilar(arg0 string, arg1, arg2 float64) ([]models.Vendor, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +272,20 @@ func (mr *MockUsecaseMockRecorder) GetVendorOwner(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorOwner", reflect.TypeOf((*MockUsecase)(nil).GetVendorOwner), arg0)
 }
 
+// SetPaused mocks base method
+func (m *MockUsecase) SetPaused(arg0 int, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaused indicates an expected call of SetPaused
+func (mr *MockUsecaseMockRecorder) SetPaused(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockUsecase)(nil).SetPaused), arg0, arg1)
+}
+
 // Update mocks base method
 func (m *MockUsecase) Update(arg0 models.Vendor) error {
 	m.ctrl.T.Helper()
@@ -241,6 +300,34 @@ func (mr *MockUsecaseMockRecorder) Update(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsecase)(nil).Update), arg0)
 }
 
+// UpdateDeliverySettings mocks base method
+func (m *MockUsecase) UpdateDeliverySettings(arg0 int, arg1 models.DeliverySettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliverySettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeliverySettings indicates an expected call of UpdateDeliverySettings
+func (mr *MockUsecaseMockRecorder) UpdateDeliverySettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliverySettings", reflect.TypeOf((*MockUsecase)(nil).UpdateDeliverySettings), arg0, arg1)
+}
+
+// UpdateDeliveryZone mocks base method
+func (m *MockUsecase) UpdateDeliveryZone(arg0 int, arg1 models.DeliveryZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliveryZone", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeliveryZone indicates an expected call of UpdateDeliveryZone
+func (mr *MockUsecaseMockRecorder) UpdateDeliveryZone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliveryZone", reflect.TypeOf((*MockUsecase)(nil).UpdateDeliveryZone), arg0, arg1)
+}
+
 // UpdateProduct mocks base method
 func (m *MockUsecase) UpdateProduct(arg0 models.Product) error {
 	m.ctrl.T.Helper()
@@ -270,6 +357,20 @@ func (mr *MockUsecaseMockRecorder) UpdateProductPicture(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductPicture", reflect.TypeOf((*MockUsecase)(nil).UpdateProductPicture), arg0, arg1, arg2)
 }
 
+// UpdateSchedule mocks base method
+func (m *MockUsecase) UpdateSchedule(arg0 int, arg1 models.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule
+func (mr *MockUsecaseMockRecorder) UpdateSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockUsecase)(nil).UpdateSchedule), arg0, arg1)
+}
+
 // UpdateVendorPicture mocks base method
 func (m *MockUsecase) UpdateVendorPicture(arg0 string, arg1 multipart.File, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,44 @@
+package vendors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+var ErrWrongZone = errors.New("wrong delivery zone")
+
+const minRingPoints = 4
+
+// CheckDeliveryZone validates a GeoJSON MultiPolygon uploaded by a partner.
+// A zone without polygons resets the vendor to its service radius.
+func CheckDeliveryZone(zone models.DeliveryZone) error {
+	if zone.Type != models.GeoJSONMultiPolygon {
+		return fmt.Errorf("%w: expected %v, got %q", ErrWrongZone, models.GeoJSONMultiPolygon, zone.Type)
+	}
+
+	for i, polygon := range zone.Coordinates {
+		if len(polygon) == 0 {
+			return fmt.Errorf("%w: polygon %v has no rings", ErrWrongZone, i)
+		}
+
+		for _, ring := range polygon {
+			if len(ring) < minRingPoints {
+				return fmt.Errorf("%w: polygon %v has a ring of %v points", ErrWrongZone, i, len(ring))
+			}
+
+			if ring[0] != ring[len(ring)-1] {
+				return fmt.Errorf("%w: polygon %v has an open ring", ErrWrongZone, i)
+			}
+
+			for _, point := range ring {
+				if point[0] < -180 || point[0] > 180 || point[1] < -90 || point[1] > 90 {
+					return fmt.Errorf("%w: point %v is out of range", ErrWrongZone, point)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package vendors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/friends/internal/pkg/models"
+)
+
+func TestCheckDeliveryZone(t *testing.T) {
+	square := [][2]float64{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.8}, {37.5, 55.7}}
+
+	tests := []struct {
+		zone models.DeliveryZone
+		err  error
+	}{
+		{
+			zone: models.DeliveryZone{Type: models.GeoJSONMultiPolygon, Coordinates: [][][][2]float64{{square}}},
+		},
+		{
+			zone: models.DeliveryZone{Type: models.GeoJSONMultiPolygon},
+		},
+		{
+			zone: models.DeliveryZone{Type: "Polygon", Coordinates: [][][][2]float64{{square}}},
+			err:  ErrWrongZone,
+		},
+		{
+			zone: models.DeliveryZone{Type: models.GeoJSONMultiPolygon, Coordinates: [][][][2]float64{{}}},
+			err:  ErrWrongZone,
+		},
+		{
+			zone: models.DeliveryZone{Type: models.GeoJSONMultiPolygon, Coordinates: [][][][2]float64{{square[:4]}}},
+			err:  ErrWrongZone,
+		},
+		{
+			zone: models.DeliveryZone{Type: models.GeoJSONMultiPolygon, Coordinates: [][][][2]float64{{square[:3]}}},
+			err:  ErrWrongZone,
+		},
+		{
+			zone: models.DeliveryZone{
+				Type:        models.GeoJSONMultiPolygon,
+				Coordinates: [][][][2]float64{{{{0, 0}, {200, 0}, {0, 1}, {0, 0}}}},
+			},
+			err: ErrWrongZone,
+		},
+	}
+
+	for i, test := range tests {
+		err := CheckDeliveryZone(test.zone)
+		if !errors.Is(err, test.err) {
+			t.Errorf("case %v. expected: %v\n got: %v", i, test.err, err)
+		}
+	}
+}
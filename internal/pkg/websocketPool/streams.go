@@ -0,0 +1,133 @@
+package websocketpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/friends/configs"
+)
+
+// Event is a message of an event stream. StreamID is its place in the
+// stream of the user, not the id of a saved event. SentAt is the time it
+// reached this instance, the history is kept by it.
+type Event struct {
+	StreamID int64
+	Data     []byte
+	SentAt   time.Time
+}
+
+// streams keeps the event streams open on this instance and the recent
+// events of the users connected to it, so a reconnecting stream can catch
+// up on what it missed from an instance that kept getting them.
+type streams struct {
+	mu        *sync.Mutex
+	listeners map[string]map[chan Event]struct{}
+	history   map[string][]Event
+	lastPrune time.Time
+}
+
+func newStreams() *streams {
+	return &streams{
+		mu:        &sync.Mutex{},
+		listeners: make(map[string]map[chan Event]struct{}),
+		history:   make(map[string][]Event),
+		lastPrune: time.Now(),
+	}
+}
+
+func (s *streams) subscribe(userID string, lastStreamID int64) (<-chan Event, []Event, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backlog := make([]Event, 0)
+	if lastStreamID != 0 {
+		for _, event := range s.history[userID] {
+			if event.StreamID > lastStreamID {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	listener := make(chan Event, configs.WebsocketSendQueue)
+	listeners, ok := s.listeners[userID]
+	if !ok {
+		listeners = make(map[chan Event]struct{})
+		s.listeners[userID] = listeners
+	}
+	listeners[listener] = struct{}{}
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.remove(userID, listener)
+	}
+
+	return listener, backlog, cancel
+}
+
+// remove must be called under the lock. The listener is closed only once,
+// either here or when it's dropped for being slow.
+func (s *streams) remove(userID string, listener chan Event) {
+	listeners, ok := s.listeners[userID]
+	if !ok {
+		return
+	}
+
+	if _, ok := listeners[listener]; !ok {
+		return
+	}
+
+	close(listener)
+	delete(listeners, listener)
+	if len(listeners) == 0 {
+		delete(s.listeners, userID)
+	}
+}
+
+func (s *streams) deliver(userID string, event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.history[userID] = append(s.history[userID], event)
+	s.history[userID] = retained(s.history[userID], now)
+	if now.Sub(s.lastPrune) > configs.EventRetention {
+		s.prune(now)
+	}
+
+	for listener := range s.listeners[userID] {
+		select {
+		case listener <- event:
+		default:
+			s.remove(userID, listener)
+		}
+	}
+}
+
+// prune drops the history of the users who got no events for a while.
+func (s *streams) prune(now time.Time) {
+	for userID, events := range s.history {
+		events = retained(events, now)
+		if len(events) == 0 {
+			delete(s.history, userID)
+			continue
+		}
+		s.history[userID] = events
+	}
+	s.lastPrune = now
+}
+
+func retained(events []Event, now time.Time) []Event {
+	oldest := now.Add(-configs.EventRetention)
+
+	first := 0
+	if len(events) > configs.EventRetentionSize {
+		first = len(events) - configs.EventRetentionSize
+	}
+	for first < len(events) && events[first].SentAt.Before(oldest) {
+		first++
+	}
+
+	return events[first:]
+}
@@ -0,0 +1,52 @@
+package websocketpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/friends/configs"
+)
+
+func TestRetained(t *testing.T) {
+	now := time.Now()
+
+	events := []Event{
+		{StreamID: 1, SentAt: now.Add(-configs.EventRetention * 2)},
+		{StreamID: 2, SentAt: now.Add(-time.Second)},
+	}
+
+	kept := retained(events, now)
+	if len(kept) != 1 || kept[0].StreamID != events[1].StreamID {
+		t.Errorf("expected only the recent event. Got: %v", kept)
+	}
+
+	events = make([]Event, 0)
+	for i := 0; i < configs.EventRetentionSize+10; i++ {
+		events = append(events, Event{StreamID: int64(i + 1), SentAt: now})
+	}
+
+	kept = retained(events, now)
+	if len(kept) != configs.EventRetentionSize || kept[0].StreamID != events[10].StreamID {
+		t.Errorf("expected the last %v events. Got: %v", configs.EventRetentionSize, len(kept))
+	}
+}
+
+func TestSlowStreamIsClosed(t *testing.T) {
+	s := newStreams()
+
+	eventChan, _, cancel := s.subscribe("1", 0)
+	defer cancel()
+
+	for i := 0; i <= configs.WebsocketSendQueue; i++ {
+		s.deliver("1", Event{StreamID: int64(i + 1)})
+	}
+
+	count := 0
+	for range eventChan {
+		count++
+	}
+
+	if count != configs.WebsocketSendQueue {
+		t.Errorf("expected %v queued events before close. Got: %v", configs.WebsocketSendQueue, count)
+	}
+}
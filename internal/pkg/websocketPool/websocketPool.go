@@ -1,36 +1,332 @@
 package websocketpool
 
 import (
+	"encoding/binary"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/friends/configs"
+	"github.com/friends/internal/pkg/broker"
 	"github.com/gorilla/websocket"
+	"github.com/lithammer/shortuuid"
 )
 
+// client owns the writes to a connection. Messages are queued to send and
+// written one by one by its writer goroutine, since gorilla/websocket
+// doesn't allow concurrent writers.
+type client struct {
+	id        string
+	conn      *websocket.Conn
+	send      chan []byte
+	done      chan struct{}
+	closeOnce *sync.Once
+}
+
+func newClient(conn *websocket.Conn) client {
+	c := client{
+		id:        shortuuid.New(),
+		conn:      conn,
+		send:      make(chan []byte, configs.WebsocketSendQueue),
+		done:      make(chan struct{}),
+		closeOnce: &sync.Once{},
+	}
+
+	go c.writer()
+
+	return c
+}
+
+// writer also pings the peer, so a dead connection misses the pongs and its
+// reader fails on the read deadline.
+func (c client) writer() {
+	ticker := time.NewTicker(configs.WebsocketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			err := c.write(websocket.TextMessage, msg)
+			if err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			err := c.write(websocket.PingMessage, nil)
+			if err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c client) write(messageType int, data []byte) error {
+	err := c.conn.SetWriteDeadline(time.Now().Add(configs.WebsocketWriteWait))
+	if err != nil {
+		return err
+	}
+
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// enqueue doesn't block, false means the queue is full.
+func (c client) enqueue(msg []byte) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the writer and closes the connection, so the reader of the
+// connection fails and removes it from the pool.
+func (c client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
+
+// WebsocketPool keeps every open connection of a user, so a user signed in
+// on several devices gets the messages on all of them. Messages go through
+// the broker, so they also reach connections held by other instances.
+// The instance is subscribed to a user while it holds a connection or an
+// event stream of the user.
 type WebsocketPool struct {
-	pool map[string]*websocket.Conn
-	mux  *sync.RWMutex
+	pool        map[string]map[*websocket.Conn]client
+	mux         *sync.RWMutex
+	broker      broker.Broker
+	streams     *streams
+	listeners   map[string]int
+	listenersMu *sync.Mutex
 }
 
-func NewWebsocketPool() WebsocketPool {
+func NewWebsocketPool(broker broker.Broker) WebsocketPool {
 	return WebsocketPool{
-		pool: make(map[string]*websocket.Conn),
-		mux:  &sync.RWMutex{},
+		pool:        make(map[string]map[*websocket.Conn]client),
+		mux:         &sync.RWMutex{},
+		broker:      broker,
+		streams:     newStreams(),
+		listeners:   make(map[string]int),
+		listenersMu: &sync.Mutex{},
 	}
 }
 
-func (w WebsocketPool) Add(userID string, conn *websocket.Conn) {
-	w.mux.RLock()
-	w.pool[userID] = conn
-	w.mux.RUnlock()
+// Listen subscribes the pool to the broker. Until then messages published
+// for the users of this instance are lost.
+func (w WebsocketPool) Listen() error {
+	return w.broker.Subscribe(w.deliver)
+}
+
+// Prepare sets the read limits of the connection and keeps its read deadline
+// moving while the peer answers the pings.
+func Prepare(conn *websocket.Conn) error {
+	conn.SetReadLimit(configs.WebsocketMaxMessageSize)
+
+	err := conn.SetReadDeadline(time.Now().Add(configs.WebsocketPongWait))
+	if err != nil {
+		return err
+	}
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(configs.WebsocketPongWait))
+	})
+
+	return nil
+}
+
+// Add registers the connection, starts its writer and marks the user online.
+// After that the connection must only be written to through Send.
+func (w WebsocketPool) Add(userID string, conn *websocket.Conn) error {
+	w.mux.Lock()
+	clients, ok := w.pool[userID]
+	if !ok {
+		clients = make(map[*websocket.Conn]client)
+		w.pool[userID] = clients
+	}
+
+	if _, ok := clients[conn]; ok {
+		w.mux.Unlock()
+		return nil
+	}
+	c := newClient(conn)
+	clients[conn] = c
+	w.mux.Unlock()
+
+	err := w.listen(userID)
+	if err != nil {
+		w.remove(userID, conn)
+		return err
+	}
+
+	// Every pong keeps the user online for one more pong wait. If the
+	// presence can't be refreshed the connection still works, the user
+	// just looks offline to the others.
+	conn.SetPongHandler(func(string) error {
+		_ = w.broker.SetOnline(userID, c.id, configs.WebsocketPongWait)
+		return conn.SetReadDeadline(time.Now().Add(configs.WebsocketPongWait))
+	})
+
+	return w.broker.SetOnline(userID, c.id, configs.WebsocketPongWait)
 }
 
-func (w WebsocketPool) Delete(userID string) {
+// Delete removes a single connection of the user, leaving the others open.
+func (w WebsocketPool) Delete(userID string, conn *websocket.Conn) error {
+	c, ok := w.remove(userID, conn)
+	if !ok {
+		return nil
+	}
+
+	offlineErr := w.broker.SetOffline(userID, c.id)
+
+	err := w.unlisten(userID)
+	if err != nil {
+		return err
+	}
+
+	return offlineErr
+}
+
+// remove closes the connection and drops it from the pool, false means it
+// wasn't there.
+func (w WebsocketPool) remove(userID string, conn *websocket.Conn) (client, bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	clients, ok := w.pool[userID]
+	if !ok {
+		return client{}, false
+	}
+
+	c, ok := clients[conn]
+	if !ok {
+		return client{}, false
+	}
+
+	c.close()
+	delete(clients, conn)
+	if len(clients) == 0 {
+		delete(w.pool, userID)
+	}
+
+	return c, true
+}
+
+// listen subscribes the instance to the user on the first local connection
+// or event stream of the user.
+func (w WebsocketPool) listen(userID string) error {
+	w.listenersMu.Lock()
+	defer w.listenersMu.Unlock()
+
+	if w.listeners[userID] == 0 {
+		err := w.broker.SubscribeUser(userID)
+		if err != nil {
+			return err
+		}
+	}
+	w.listeners[userID]++
+
+	return nil
+}
+
+// unlisten unsubscribes the instance from the user once the last local
+// connection or event stream of the user is closed.
+func (w WebsocketPool) unlisten(userID string) error {
+	w.listenersMu.Lock()
+	defer w.listenersMu.Unlock()
+
+	w.listeners[userID]--
+	if w.listeners[userID] > 0 {
+		return nil
+	}
+	delete(w.listeners, userID)
+
+	return w.broker.UnsubscribeUser(userID)
+}
+
+// Subscribe opens an event stream of the user. The returned events are the
+// retained ones following lastStreamID, the channel brings the new ones and
+// is closed if the stream falls behind. cancel must be called when the
+// stream is done.
+func (w WebsocketPool) Subscribe(userID string, lastStreamID int64) (<-chan Event, []Event, func(), error) {
+	err := w.listen(userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	events, backlog, cancelStream := w.streams.subscribe(userID, lastStreamID)
+	cancel := func() {
+		cancelStream()
+		_ = w.unlisten(userID)
+	}
+
+	return events, backlog, cancel, nil
+}
+
+// Presence tells whether the user is connected to any instance and, if not,
+// when the last connection was closed. lastSeen is zero when unknown.
+func (w WebsocketPool) Presence(userID string) (online bool, lastSeen time.Time, err error) {
+	return w.broker.Presence(userID)
+}
+
+// Send publishes the message for every connection and event stream of the
+// user. The message gets a stream id from the broker, growing across all the
+// instances, so a stream can resume after it on any instance that got it.
+func (w WebsocketPool) Send(userID string, msg []byte) error {
+	id, err := w.broker.NextStreamID(userID)
+	if err != nil {
+		return err
+	}
+
+	packet := make([]byte, 8+len(msg))
+	binary.BigEndian.PutUint64(packet, uint64(id))
+	copy(packet[8:], msg)
+
+	return w.broker.Publish(userID, packet)
+}
+
+// deliver queues the message for the local connections of the user. A client
+// whose queue is full can't keep up, it's disconnected instead of blocking
+// the sender or piling up memory.
+func (w WebsocketPool) deliver(userID string, packet []byte) error {
+	if len(packet) < 8 {
+		return fmt.Errorf("wrong packet for user %v", userID)
+	}
+	msg := packet[8:]
+
+	w.streams.deliver(userID, Event{
+		StreamID: int64(binary.BigEndian.Uint64(packet)),
+		Data:     msg,
+		SentAt:   time.Now(),
+	})
+
 	w.mux.RLock()
-	delete(w.pool, userID)
+	clients := w.pool[userID]
+	slow := make([]*websocket.Conn, 0)
+	for conn, c := range clients {
+		if !c.enqueue(msg) {
+			slow = append(slow, conn)
+		}
+	}
 	w.mux.RUnlock()
-}
 
-func (w WebsocketPool) Get(userID string) (*websocket.Conn, bool) {
-	conn, ok := w.pool[userID]
-	return conn, ok
+	for _, conn := range slow {
+		_ = w.Delete(userID, conn)
+	}
+
+	if len(slow) != 0 {
+		return fmt.Errorf("dropped %v slow connections of user %v", len(slow), userID)
+	}
+
+	return nil
 }
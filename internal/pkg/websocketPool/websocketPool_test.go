@@ -0,0 +1,285 @@
+package websocketpool
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/friends/internal/pkg/broker"
+	"github.com/friends/internal/pkg/broker/memory"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+)
+
+func newTestServer(t *testing.T, conns chan *websocket.Conn) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		conns <- conn
+	}))
+}
+
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("couldn't dial: %v", err)
+	}
+	return conn
+}
+
+func TestSendToAllConnections(t *testing.T) {
+	serverConns := make(chan *websocket.Conn, 2)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	tablet := dial(t, server)
+	defer tablet.Close()
+	laptop := dial(t, server)
+	defer laptop.Close()
+
+	pool := NewWebsocketPool(memory.New())
+	_ = pool.Listen()
+	first := <-serverConns
+	second := <-serverConns
+	_ = pool.Add("1", first)
+	_ = pool.Add("1", second)
+
+	if online, _, _ := pool.Presence("1"); !online {
+		t.Fatalf("expected user to be online")
+	}
+
+	err := pool.Send("1", []byte("hello"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, client := range []*websocket.Conn{tablet, laptop} {
+		_, msg, err := client.ReadMessage()
+		if err != nil || string(msg) != "hello" {
+			t.Errorf("expected: hello\n got: %s, %v", msg, err)
+		}
+	}
+
+	// one device disconnects
+	_ = pool.Delete("1", first)
+
+	if online, _, _ := pool.Presence("1"); !online {
+		t.Errorf("expected user to stay online")
+	}
+
+	err = pool.Send("1", []byte("still here"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, msg, err := laptop.ReadMessage()
+	if err != nil || string(msg) != "still here" {
+		t.Errorf("expected: still here\n got: %s, %v", msg, err)
+	}
+
+	_, _, err = tablet.ReadMessage()
+	if err == nil {
+		t.Errorf("expected the deleted connection to be closed")
+	}
+
+	_ = pool.Delete("1", second)
+
+	if online, _, _ := pool.Presence("1"); online {
+		t.Errorf("expected user to be removed")
+	}
+}
+
+func TestSendDropsSlowClient(t *testing.T) {
+	serverConns := make(chan *websocket.Conn, 1)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	slowClient := dial(t, server)
+	defer slowClient.Close()
+
+	pool := NewWebsocketPool(memory.New())
+	_ = pool.Listen()
+	conn := <-serverConns
+
+	// no writer drains the queue, so it's full right away
+	pool.pool["1"] = map[*websocket.Conn]client{
+		conn: {
+			conn:      conn,
+			send:      make(chan []byte),
+			done:      make(chan struct{}),
+			closeOnce: &sync.Once{},
+		},
+	}
+
+	err := pool.Send("1", []byte("hello"))
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	if _, ok := pool.pool["1"]; ok {
+		t.Errorf("expected slow client to be dropped")
+	}
+
+	_, _, err = slowClient.ReadMessage()
+	if err == nil {
+		t.Errorf("expected the slow connection to be closed")
+	}
+}
+
+func TestSendOffline(t *testing.T) {
+	pool := NewWebsocketPool(memory.New())
+	_ = pool.Listen()
+
+	err := pool.Send("1", []byte("hello"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSendAcrossInstances(t *testing.T) {
+	serverConns := make(chan *websocket.Conn, 1)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	client := dial(t, server)
+	defer client.Close()
+
+	broker := memory.New()
+	sender := NewWebsocketPool(broker)
+	_ = sender.Listen()
+	receiver := NewWebsocketPool(broker)
+	_ = receiver.Listen()
+
+	_ = receiver.Add("1", <-serverConns)
+
+	err := sender.Send("1", []byte("hello"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, msg, err := client.ReadMessage()
+	if err != nil || string(msg) != "hello" {
+		t.Errorf("expected: hello\n got: %s, %v", msg, err)
+	}
+}
+
+func TestPresence(t *testing.T) {
+	serverConns := make(chan *websocket.Conn, 1)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	client := dial(t, server)
+	defer client.Close()
+
+	broker := memory.New()
+	pool := NewWebsocketPool(broker)
+	other := NewWebsocketPool(broker)
+
+	online, lastSeen, err := pool.Presence("1")
+	if online || !lastSeen.IsZero() || err != nil {
+		t.Errorf("expected unknown user to be offline without last seen")
+	}
+
+	conn := <-serverConns
+	err = pool.Add("1", conn)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// presence is shared by the instances
+	online, _, _ = other.Presence("1")
+	if !online {
+		t.Errorf("expected user to be online")
+	}
+
+	before := time.Now()
+	err = pool.Delete("1", conn)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	online, lastSeen, _ = other.Presence("1")
+	if online || lastSeen.Before(before) {
+		t.Errorf("expected user to be offline and last seen at disconnect. Got: %v, %v", online, lastSeen)
+	}
+}
+
+func TestSubscribeUserWhileConnected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverConns := make(chan *websocket.Conn, 2)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	tablet := dial(t, server)
+	defer tablet.Close()
+	laptop := dial(t, server)
+	defer laptop.Close()
+
+	mockBroker := broker.NewMockBroker(ctrl)
+	pool := NewWebsocketPool(mockBroker)
+	first := <-serverConns
+	second := <-serverConns
+
+	mockBroker.EXPECT().SetOnline("1", gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockBroker.EXPECT().SetOffline("1", gomock.Any()).Times(2).Return(nil)
+
+	// subscribed on the first connection only
+	mockBroker.EXPECT().SubscribeUser("1").Times(1).Return(nil)
+	_ = pool.Add("1", first)
+	_ = pool.Add("1", second)
+	_, _, cancel, err := pool.Subscribe("1", 0)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_ = pool.Delete("1", first)
+	_ = pool.Delete("1", second)
+
+	// unsubscribed once the event stream is closed too
+	mockBroker.EXPECT().UnsubscribeUser("1").Times(1).Return(nil)
+	cancel()
+}
+
+func TestSubscribeUserError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverConns := make(chan *websocket.Conn, 1)
+	server := newTestServer(t, serverConns)
+	defer server.Close()
+
+	client := dial(t, server)
+	defer client.Close()
+
+	mockBroker := broker.NewMockBroker(ctrl)
+	pool := NewWebsocketPool(mockBroker)
+	conn := <-serverConns
+
+	mockBroker.EXPECT().SubscribeUser("1").Times(2).Return(fmt.Errorf("broker error"))
+
+	err := pool.Add("1", conn)
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+
+	// the connection isn't kept
+	err = pool.Delete("1", conn)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, _, _, err = pool.Subscribe("1", 0)
+	if err == nil {
+		t.Errorf("expected error. Got nil")
+	}
+}